@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"           // Rejani chiqarish uchun
+	"main/models"   // Dastur tuzilmalari
+	"main/services" // Reja va o‘rnatish navbati
+	"main/storage"  // Manifestni o‘qish uchun
+	"strings"       // Sozlash o‘zgarishlarini birlashtirish uchun
+)
+
+// planView - rejadagi bitta amal (JSON uchun)
+type planView struct {
+	Action  string   `json:"action"` // install, update, rollback, remove, configure
+	ID      string   `json:"id"`
+	Name    string   `json:"name,omitempty"`
+	From    string   `json:"from,omitempty"`    // Joriy versiya
+	To      string   `json:"to,omitempty"`      // Natijadagi versiya
+	Changes []string `json:"changes,omitempty"` // Sozlash o‘zgarishlari
+	Error   string   `json:"error,omitempty"`   // Amalni bajarib bo‘lmaydi
+}
+
+// planSymbols - amallarning qisqa belgilari va nomlari (inson o‘qiydigan reja uchun)
+var planSymbols = map[services.ActionKind]string{
+	services.ActionInstall:   "+ o'rnatish ",
+	services.ActionUpdate:    "~ yangilash ",
+	services.ActionRollback:  "< qaytarish ",
+	services.ActionRemove:    "- o'chirish ",
+	services.ActionConfigure: "* sozlash   ",
+}
+
+// runApply - kompyuterni manifestga moslashtiradi: avval o‘chiradi, so‘ng navbat orqali o‘rnatadi,
+// yangilaydi va qaytaradi, oxirida sozlamalarni qo‘llaydi. Rejada bajarib bo‘lmaydigan amal bo‘lsa,
+// hech narsa o‘zgartirilmaydi.
+func runApply(e *env, args []string) int {
+	manifest, err := storage.LoadManifest(args[0])
+	if err != nil {
+		e.errorf("%v", err)
+		return ExitUsage
+	}
+	actions, errs := e.installer.Plan(e.ctx, manifest)
+	for _, err := range errs {
+		e.errorf("%v", err)
+	}
+
+	blocked := false // Rejada bajarib bo‘lmaydigan amal bor
+	for _, action := range actions {
+		blocked = blocked || action.Err != nil
+	}
+	if e.dryRun || blocked || len(actions) == 0 {
+		e.printPlan(actions)
+		if blocked {
+			e.errorf("reja bajarilmadi: yuqoridagi xatoliklarni tuzating")
+		}
+		switch {
+		case len(errs) > 0 && (blocked || len(actions) == 0): // Katalog manbalari ishlamagani sababli
+			return ExitUnavailable
+		case blocked:
+			return ExitError
+		}
+		return ExitOK
+	}
+	if !e.json {
+		e.printPlan(actions)
+	}
+
+	var (
+		results    []resultView
+		jobs       []*services.Job
+		configures []services.Action
+	)
+	for _, action := range actions {
+		switch action.Kind {
+		case services.ActionRemove: // O‘chirishlar darhol (joy bo‘shatadi)
+			record, err := e.installer.Remove(action.ID)
+			if err != nil {
+				results = append(results, failed(action.ID, "remove", err))
+				continue
+			}
+			results = append(results, resultView{ID: action.ID, Name: record.Name, Action: "remove", Version: record.Version, OK: true})
+		case services.ActionInstall:
+			jobs = append(jobs, e.queue.Enqueue(services.JobInstall, action.Software))
+		case services.ActionUpdate:
+			jobs = append(jobs, e.queue.Enqueue(services.JobUpdate, action.Software))
+		case services.ActionRollback:
+			jobs = append(jobs, e.queue.Enqueue(services.JobRollback, models.Software{ID: action.ID, Name: action.Name, Version: action.To}))
+		case services.ActionConfigure:
+			configures = append(configures, action)
+		}
+	}
+	results = append(results, e.wait(jobs)...)
+
+	for _, action := range configures { // O‘rnatilgan dasturlar sozlamalari
+		if err := e.installer.Configure(action.App); err != nil {
+			r := failed(action.ID, "configure", err)
+			r.Name = action.Name
+			results = append(results, r)
+			continue
+		}
+		results = append(results, resultView{ID: action.ID, Name: action.Name, Action: "configure", Message: strings.Join(action.Changes, ", "), OK: true})
+	}
+	return e.report(results)
+}
+
+// printPlan - rejani jadval yoki JSON sifatida chiqaradi
+func (e *env) printPlan(actions []services.Action) {
+	if e.json {
+		views := make([]planView, 0, len(actions))
+		for _, a := range actions {
+			v := planView{Action: string(a.Kind), ID: a.ID, Name: a.Name, From: a.From, To: a.To, Changes: a.Changes}
+			if a.Err != nil {
+				v.Error = a.Err.Error()
+			}
+			views = append(views, v)
+		}
+		e.printJSON(views)
+		return
+	}
+	if len(actions) == 0 {
+		fmt.Fprintln(e.stdout, "O'zgarish yo'q: kompyuter manifestga mos")
+		return
+	}
+	fmt.Fprintln(e.stdout, "Reja:")
+	for _, a := range actions {
+		line := planSymbols[a.Kind] + a.ID
+		switch a.Kind {
+		case services.ActionInstall:
+			line += " " + a.To
+		case services.ActionUpdate, services.ActionRollback:
+			line += fmt.Sprintf(" %s -> %s", a.From, a.To)
+		case services.ActionRemove:
+			line += " " + a.From
+		case services.ActionConfigure:
+			line += ": " + strings.Join(a.Changes, ", ")
+		}
+		if a.Err != nil {
+			line += "  ✗ " + a.Err.Error()
+		}
+		fmt.Fprintln(e.stdout, "  "+line)
+	}
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"       // Buyruqlarni Ctrl+C bilan to‘xtatish uchun
+	"encoding/json" // --json natijasi uchun
+	"errors"        // Xatolik turlarini aniqlash uchun
+	"flag"          // Buyruq bayroqlari uchun
+	"fmt"           // Natijalarni chiqarish uchun
+	"io"            // Chiqish oqimlari uchun
+	"main/config"   // Sozlamalar
+	"main/services" // O‘rnatish navbati va katalog
+	"main/storage"  // O‘rnatilgan dasturlar ro‘yxati
+	"os"            // Signallar uchun
+	"os/signal"     // Ctrl+C ni ushlash uchun
+	"sort"          // Buyruqlar ro‘yxatini tartiblash uchun
+	"strings"       // Bayroqlarni ajratish uchun
+)
+
+// Chiqish kodlari (skriptlar shularga tayanadi)
+const (
+	ExitOK          = 0 // Muvaffaqiyatli
+	ExitError       = 1 // Buyruq bajarildi, lekin xatolik bo‘ldi (masalan o‘rnatish yoki yangilash muvaffaqiyatsiz)
+	ExitUsage       = 2 // Noma'lum buyruq yoki noto‘g‘ri argumentlar
+	ExitNotFound    = 3 // Dastur katalogda yoki o‘rnatilganlar ro‘yxatida topilmadi
+	ExitUnavailable = 4 // Katalog serveri bilan bog‘lanib bo‘lmadi
+)
+
+// env - buyruqlar uchun umumiy muhit
+type env struct {
+	cfg       *config.Config
+	installer *services.Installer
+	queue     *services.Queue
+	ctx       context.Context // Ctrl+C bosilganda bekor qilinadi
+	stdout    io.Writer
+	stderr    io.Writer
+	json      bool // Natija JSON ko‘rinishida chiqariladi
+	dryRun    bool // Faqat reja chiqariladi, hech narsa o‘zgartirilmaydi
+}
+
+// command - bitta buyruq
+type command struct {
+	args  string                          // Argumentlar tavsifi (yordam uchun)
+	help  string                          // Qisqa tavsif
+	run   func(e *env, args []string) int // Buyruqni bajaradi va chiqish kodini qaytaradi
+	nargs func(n int) bool                // Pozitsion argumentlar soni to‘g‘rimi
+	plan  bool                            // --dry-run bayrog‘ini qabul qiladi
+}
+
+var commands = map[string]command{
+	"list":       {"", "katalogdagi barcha dasturlar", runList, exactly(0), false},
+	"search":     {"<so'z>", "nomi yoki tavsifi bo'yicha qidirish", runSearch, atLeast(1), false},
+	"info":       {"<id>", "dastur haqida batafsil ma'lumot", runInfo, exactly(1), false},
+	"install":    {"<id>...", "dasturlarni o'rnatish", runInstall, atLeast(1), false},
+	"update":     {"<id>...", "dasturlarni yangilash", runUpdate, atLeast(1), false},
+	"update-all": {"", "yangilanishi mavjud barcha o'rnatilgan dasturlarni yangilash", runUpdateAll, exactly(0), false},
+	"remove":     {"<id>...", "dasturlarni o'chirish", runRemove, atLeast(1), false},
+	"rollback":   {"<id> [versiya]", "avvalgi versiyaga qaytarish", runRollback, between(1, 2), false},
+	"installed":  {"", "o'rnatilgan dasturlar", runInstalled, exactly(0), false},
+	"outdated":   {"", "yangilanishi mavjud o'rnatilgan dasturlar", runOutdated, exactly(0), false},
+	"apply":      {"<manifest> [--dry-run]", "kompyuterni manifestga moslashtirish (YAML yoki JSON)", runApply, exactly(1), true},
+}
+
+// Run - oynasiz rejimda buyruqni bajaradi va chiqish kodini qaytaradi
+func Run(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return ExitUsage
+	}
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage(stdout)
+		return ExitOK
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "Noma'lum buyruq: %s\n", name)
+		usage(stderr)
+		return ExitUsage
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jsonOut := fs.Bool("json", false, "natijani JSON ko'rinishida chiqarish")
+	var dryRun *bool
+	if cmd.plan {
+		dryRun = fs.Bool("dry-run", false, "faqat rejani chiqarish, hech narsani o'zgartirmaslik")
+	}
+	positional, err := parseInterleaved(fs, args[1:])
+	if err != nil {
+		return ExitUsage // Xabarni flag paketi chiqargan
+	}
+	if !cmd.nargs(len(positional)) {
+		fmt.Fprintf(stderr, "Foydalanish: appStore %s %s [--json]\n", name, cmd.args)
+		return ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	installer, err := services.NewInstaller(services.DefaultClient, cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, "Xatolik:", err)
+		return ExitError
+	}
+	installer.Log = stderr                    // Xizmat xabarlari natijaga (masalan --json) aralashmasligi uchun
+	for _, err := range installer.Migrate() { // Eski ro‘yxat va papkadagi dasturlar (navbat ishga tushishidan oldin)
+		fmt.Fprintln(stderr, "Xatolik:", err)
+	}
+	e := &env{
+		cfg:       cfg,
+		installer: installer,
+		queue:     services.NewQueue(installer, cfg.Workers), // Bir vaqtda cfg.Workers tagacha vazifa
+		ctx:       ctx,
+		stdout:    stdout,
+		stderr:    stderr,
+		json:      *jsonOut,
+		dryRun:    dryRun != nil && *dryRun,
+	}
+	return cmd.run(e, positional)
+}
+
+// usage - buyruqlar ro‘yxatini chiqaradi
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Foydalanish: appStore [bayroqlar] <buyruq> [argumentlar] [--json]")
+	fmt.Fprintln(w, "Buyruqlar:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd := commands[name]
+		fmt.Fprintf(w, "  %-28s %s\n", strings.TrimSpace(name+" "+cmd.args), cmd.help)
+	}
+	fmt.Fprintln(w, "Chiqish kodlari: 0 - muvaffaqiyatli, 1 - xatolik, 2 - noto'g'ri foydalanish, 3 - dastur topilmadi, 4 - katalog mavjud emas")
+}
+
+// parseInterleaved - bayroqlarni pozitsion argumentlar orasida ham qabul qiladi (masalan "install app --json")
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" { // Qolganlari faqat pozitsion
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func exactly(n int) func(int) bool      { return func(got int) bool { return got == n } }
+func atLeast(n int) func(int) bool      { return func(got int) bool { return got >= n } }
+func between(lo, hi int) func(int) bool { return func(got int) bool { return got >= lo && got <= hi } }
+
+// printJSON - qiymatni chiroyli formatlangan JSON sifatida chiqaradi
+func (e *env) printJSON(v interface{}) {
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
+
+// errorf - xatolikni stderr ga chiqaradi
+func (e *env) errorf(format string, args ...interface{}) {
+	fmt.Fprintf(e.stderr, "Xatolik: "+format+"\n", args...)
+}
+
+// exitCode - xatolik turiga mos chiqish kodi
+func exitCode(err error) int {
+	var statusErr *services.StatusError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, storage.ErrSoftwareNotFound), errors.Is(err, services.ErrNoPrevious):
+		return ExitNotFound
+	case errors.As(err, &statusErr) && statusErr.StatusCode == 404:
+		return ExitNotFound
+	case errors.Is(err, services.ErrNetwork), errors.Is(err, services.ErrTimeout):
+		return ExitUnavailable // Server bilan bog‘lanib bo‘lmadi yoki javob kelmadi
+	case errors.As(err, &statusErr) && statusErr.StatusCode >= 500:
+		return ExitUnavailable // Server ishlamayapti
+	}
+	return ExitError
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"main/services"
+	"main/storage"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"xatoliksiz", nil, ExitOK},
+		{"oddiy xatolik", errors.New("xatolik"), ExitError},
+		{"dastur topilmadi", fmt.Errorf("%w: ID = notes", storage.ErrSoftwareNotFound), ExitNotFound},
+		{"avvalgi versiya yo'q", fmt.Errorf("%w: notes", services.ErrNoPrevious), ExitNotFound},
+		{"404", &services.StatusError{StatusCode: 404}, ExitNotFound},
+		{"tarmoq xatoligi", fmt.Errorf("%w: http://localhost - refused", services.ErrNetwork), ExitUnavailable},
+		{"vaqt tugadi", fmt.Errorf("%w: http://localhost", services.ErrTimeout), ExitUnavailable},
+		{"500", &services.StatusError{StatusCode: 500}, ExitUnavailable},
+		{"503 o'ralgan", fmt.Errorf("yuklash: %w", &services.StatusError{StatusCode: 503}), ExitUnavailable},
+		{"403", &services.StatusError{StatusCode: 403}, ExitError},
+		{"yig'indi mos emas", fmt.Errorf("%w: notes", services.ErrChecksumMismatch), ExitError},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("%s: exitCode(%v) = %d, kutilgan %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,500 @@
+package cli
+
+import (
+	"fmt"            // Natijalarni chiqarish uchun
+	"main/models"    // Dastur tuzilmalari
+	"main/services"  // O‘rnatish navbati va yangilanishlar
+	"main/storage"   // O‘rnatilgan dasturlar ro‘yxati
+	"main/version"   // Versiyalarni taqqoslash uchun
+	"strings"        // Qidiruv uchun
+	"sync"           // Natijalarni himoyalash uchun
+	"text/tabwriter" // Jadval ko‘rinishidagi natija uchun
+)
+
+// Dastur holatlari (JSON natijasida)
+const (
+	statusNotInstalled    = "not-installed"    // O‘rnatilmagan
+	statusUpToDate        = "up-to-date"       // Eng so‘nggi versiya o‘rnatilgan
+	statusUpdateAvailable = "update-available" // Katalogda yangiroq versiya bor
+	statusInstalledNewer  = "installed-newer"  // O‘rnatilgan versiya katalogdagidan yangiroq
+	statusNotInCatalog    = "not-in-catalog"   // O‘rnatilgan, lekin katalogda yo‘q
+)
+
+// appView - bitta dastur haqidagi natija (jadval va JSON uchun)
+type appView struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Version     string   `json:"version,omitempty"` // Katalogdagi versiya
+	Channel     string   `json:"channel,omitempty"` // Katalogdagi versiya kanali
+	Source      string   `json:"source,omitempty"`  // Katalog manbasi
+	Description string   `json:"description,omitempty"`
+	Installed   string   `json:"installed,omitempty"` // O‘rnatilgan versiya
+	Status      string   `json:"status,omitempty"`    // Yuqoridagi holatlardan biri
+	Policy      string   `json:"policy,omitempty"`    // Yangilash siyosati (o‘rnatilganlar uchun)
+	Previous    []string `json:"previous,omitempty"`  // Orqaga qaytarish mumkin bo‘lgan versiyalar
+	DirPath     string   `json:"dirPath,omitempty"`   // O‘rnatish papkasi
+}
+
+// resultView - o‘rnatish, yangilash, o‘chirish yoki qaytarish natijasi
+type resultView struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,omitempty"`
+	Action  string `json:"action"`            // install, update, remove, rollback
+	Version string `json:"version,omitempty"` // Natijadagi versiya
+	OK      bool   `json:"ok"`
+	Skipped bool   `json:"skipped,omitempty"` // Bajarish shart emas edi (masalan allaqachon o‘rnatilgan)
+	Message string `json:"message,omitempty"` // O‘tkazib yuborish sababi
+	Error   string `json:"error,omitempty"`
+
+	code int // Chiqish kodi
+}
+
+// catalog - katalogni oladi; hech bir manba ishlamasa, ExitUnavailable qaytaradi
+func (e *env) catalog() ([]models.Software, int) {
+	softwares, errs := e.installer.FetchCatalog(e.ctx)
+	for _, err := range errs {
+		e.errorf("%v", err)
+	}
+	if len(errs) > 0 && len(softwares) == 0 {
+		return nil, ExitUnavailable
+	}
+	return softwares, ExitOK
+}
+
+// registry - o‘rnatilgan dasturlar (ID bo‘yicha)
+func (e *env) registry() (map[string]models.DownloadedSoftware, int) {
+	installed, err := storage.LoadDownloadedSoftware(e.installer.RegistryPath)
+	if err != nil {
+		e.errorf("%v", err)
+		return nil, ExitError
+	}
+	records := make(map[string]models.DownloadedSoftware, len(installed))
+	for _, record := range installed {
+		records[record.ID] = record
+	}
+	return records, ExitOK
+}
+
+// view - katalog va ro‘yxatdagi ma'lumotlardan natija yasaydi (ikkalasidan biri nil bo‘lishi mumkin)
+func view(software *models.Software, record *models.DownloadedSoftware) appView {
+	var v appView
+	if software != nil {
+		v.ID, v.Name, v.Version = software.ID, software.Name, software.Version
+		v.Channel, v.Source, v.Description = software.Channel, software.Source, software.Description
+		v.Status = statusNotInstalled
+	}
+	if record != nil {
+		v.ID, v.Installed, v.DirPath = record.ID, record.Version, record.DirPath
+		if v.Name == "" {
+			v.Name = record.Name
+		}
+		v.Policy = models.NormalizePolicy(record.UpdatePolicy)
+		for _, prev := range record.Previous {
+			v.Previous = append(v.Previous, prev.Version)
+		}
+		v.Status = statusNotInCatalog
+		if software != nil {
+			switch version.Check(record.Version, software.Version) {
+			case version.UpdateAvailable:
+				v.Status = statusUpdateAvailable
+			case version.InstalledNewer:
+				v.Status = statusInstalledNewer
+			default:
+				v.Status = statusUpToDate
+			}
+		}
+	}
+	return v
+}
+
+// printApps - dasturlar ro‘yxatini jadval yoki JSON sifatida chiqaradi
+func (e *env) printApps(views []appView) {
+	if e.json {
+		if views == nil {
+			views = []appView{} // JSON da null emas, bo‘sh ro‘yxat
+		}
+		e.printJSON(views)
+		return
+	}
+	if len(views) == 0 {
+		fmt.Fprintln(e.stdout, "Dastur topilmadi")
+		return
+	}
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNOMI\tKATALOGDA\tO'RNATILGAN\tHOLAT")
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", v.ID, v.Name, dash(v.Version), dash(v.Installed), v.Status)
+	}
+	tw.Flush()
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// listViews - katalogdagi dasturlardan match ga mos kelganlarini qaytaradi
+func (e *env) listViews(match func(models.Software) bool) ([]appView, int) {
+	softwares, code := e.catalog()
+	if code != ExitOK {
+		return nil, code
+	}
+	records, code := e.registry()
+	if code != ExitOK {
+		return nil, code
+	}
+	var views []appView
+	for i := range softwares {
+		if !match(softwares[i]) {
+			continue
+		}
+		var record *models.DownloadedSoftware
+		if r, ok := records[softwares[i].ID]; ok {
+			record = &r
+		}
+		views = append(views, view(&softwares[i], record))
+	}
+	return views, ExitOK
+}
+
+func runList(e *env, _ []string) int {
+	views, code := e.listViews(func(models.Software) bool { return true })
+	if code != ExitOK {
+		return code
+	}
+	e.printApps(views)
+	return ExitOK
+}
+
+func runSearch(e *env, args []string) int {
+	query := strings.ToLower(strings.Join(args, " "))
+	views, code := e.listViews(func(s models.Software) bool { // UI dagi qidiruv bilan bir xil
+		return strings.Contains(strings.ToLower(s.Name), query) || strings.Contains(strings.ToLower(s.Description), query)
+	})
+	if code != ExitOK {
+		return code
+	}
+	e.printApps(views)
+	return ExitOK
+}
+
+func runInfo(e *env, args []string) int {
+	id := args[0]
+	softwares, code := e.catalog()
+	records, regCode := e.registry()
+	if regCode != ExitOK {
+		return regCode
+	}
+	var software *models.Software
+	for i := range softwares {
+		if softwares[i].ID == id {
+			software = &softwares[i]
+		}
+	}
+	var record *models.DownloadedSoftware
+	if r, ok := records[id]; ok {
+		record = &r
+	}
+	if software == nil && record == nil {
+		if code != ExitOK {
+			return code // Katalog mavjud emas, dastur o‘rnatilmagan
+		}
+		e.errorf("dastur topilmadi: %s", id)
+		return ExitNotFound
+	}
+
+	v := view(software, record)
+	if software == nil && code != ExitOK {
+		v.Status = "" // Katalog so‘ralmadi, holat noma'lum
+	}
+	if e.json {
+		e.printJSON(v)
+		return ExitOK
+	}
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	for _, row := range [][2]string{
+		{"ID", v.ID}, {"Nomi", v.Name}, {"Tavsif", v.Description},
+		{"Katalogda", v.Version}, {"Kanal", v.Channel}, {"Manba", v.Source},
+		{"O'rnatilgan", v.Installed}, {"Papka", v.DirPath}, {"Yangilash siyosati", v.Policy},
+		{"Avvalgi versiyalar", strings.Join(v.Previous, ", ")}, {"Holat", v.Status},
+	} {
+		if row[1] != "" {
+			fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1])
+		}
+	}
+	tw.Flush()
+	return ExitOK
+}
+
+func runInstalled(e *env, _ []string) int {
+	installed, err := storage.LoadDownloadedSoftware(e.installer.RegistryPath)
+	if err != nil {
+		e.errorf("%v", err)
+		return ExitError
+	}
+	views := make([]appView, 0, len(installed))
+	for i := range installed {
+		v := view(nil, &installed[i])
+		v.Status = "" // Katalog so‘ralmagan
+		views = append(views, v)
+	}
+	if e.json {
+		e.printJSON(views)
+		return ExitOK
+	}
+	if len(views) == 0 {
+		fmt.Fprintln(e.stdout, "O'rnatilgan dastur yo'q")
+		return ExitOK
+	}
+	tw := tabwriter.NewWriter(e.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNOMI\tVERSIYA\tSIYOSAT\tPAPKA")
+	for _, v := range views {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", v.ID, v.Name, v.Installed, v.Policy, v.DirPath)
+	}
+	tw.Flush()
+	return ExitOK
+}
+
+func runOutdated(e *env, _ []string) int {
+	updates, errs := services.NewUpdater(e.queue, 0).Check(e.ctx)
+	for _, err := range errs {
+		e.errorf("%v", err)
+	}
+	if len(errs) > 0 && len(updates) == 0 {
+		return ExitUnavailable
+	}
+	views := make([]appView, 0, len(updates))
+	for i := range updates {
+		views = append(views, view(&updates[i].Available, &updates[i].Installed))
+	}
+	if !e.json && len(views) == 0 {
+		fmt.Fprintln(e.stdout, "Barcha dasturlar eng so'nggi versiyada")
+		return ExitOK
+	}
+	e.printApps(views)
+	return ExitOK
+}
+
+func runInstall(e *env, args []string) int {
+	softwares, code := e.catalog()
+	if code != ExitOK {
+		return code
+	}
+	records, code := e.registry()
+	if code != ExitOK {
+		return code
+	}
+	var (
+		results []resultView
+		jobs    []*services.Job
+	)
+	for _, id := range args {
+		software, ok := find(softwares, id)
+		switch {
+		case !ok:
+			results = append(results, failed(id, "install", fmt.Errorf("%w: ID = %s", storage.ErrSoftwareNotFound, id)))
+		case hasRecord(records, id):
+			results = append(results, skipped(id, software.Name, "install", records[id].Version, "allaqachon o'rnatilgan"))
+		default:
+			jobs = append(jobs, e.queue.Enqueue(services.JobInstall, software))
+		}
+	}
+	return e.finish(jobs, results)
+}
+
+func runUpdate(e *env, args []string) int {
+	softwares, code := e.catalog()
+	if code != ExitOK {
+		return code
+	}
+	records, code := e.registry()
+	if code != ExitOK {
+		return code
+	}
+	var (
+		results []resultView
+		jobs    []*services.Job
+	)
+	for _, id := range args {
+		record, installed := records[id]
+		software, ok := find(softwares, id)
+		switch {
+		case !installed:
+			results = append(results, failed(id, "update", fmt.Errorf("%w: ID = %s", storage.ErrSoftwareNotFound, id)))
+		case !ok:
+			results = append(results, failed(id, "update", fmt.Errorf("katalogda topilmadi: %w: ID = %s", storage.ErrSoftwareNotFound, id)))
+		case version.Check(record.Version, software.Version) != version.UpdateAvailable:
+			results = append(results, skipped(id, record.Name, "update", record.Version, version.Check(record.Version, software.Version).String()))
+		default:
+			jobs = append(jobs, e.queue.Enqueue(services.JobUpdate, software))
+		}
+	}
+	return e.finish(jobs, results)
+}
+
+func runUpdateAll(e *env, _ []string) int {
+	jobs, errs := services.NewUpdater(e.queue, 0).UpdateOutdated(e.ctx)
+	for _, err := range errs {
+		e.errorf("%v", err)
+	}
+	if len(jobs) == 0 && len(errs) > 0 {
+		return ExitUnavailable
+	}
+	if len(jobs) == 0 && !e.json {
+		fmt.Fprintln(e.stdout, "Barcha dasturlar eng so'nggi versiyada")
+		return ExitOK
+	}
+	return e.finish(jobs, nil)
+}
+
+func runRemove(e *env, args []string) int {
+	var results []resultView
+	for _, id := range args {
+		record, err := e.installer.Remove(id) // Papka, yozuv, avvalgi versiyalar va yorliqlar
+		if err != nil {
+			results = append(results, failed(id, "remove", err))
+			continue
+		}
+		results = append(results, resultView{ID: id, Name: record.Name, Action: "remove", Version: record.Version, OK: true})
+	}
+	return e.finish(nil, results)
+}
+
+func runRollback(e *env, args []string) int {
+	id, target := args[0], ""
+	if len(args) > 1 {
+		target = args[1] // Bo‘sh bo‘lsa, eng yangi avvalgi versiya
+	}
+	record, err := storage.GetSoftwareByID(id, e.installer.RegistryPath)
+	if err != nil {
+		return e.finish(nil, []resultView{failed(id, "rollback", err)})
+	}
+	job := e.queue.Enqueue(services.JobRollback, models.Software{ID: id, Name: record.Name, Version: target})
+	return e.finish([]*services.Job{job}, nil)
+}
+
+// finish - vazifalar tugashini kutadi, so‘ng oldingi natijalar bilan birga xulosani chiqaradi
+func (e *env) finish(jobs []*services.Job, results []resultView) int {
+	return e.report(append(results, e.wait(jobs)...))
+}
+
+// wait - vazifalar tugashini kutadi (Ctrl+C ularni bekor qiladi) va natijalarini qaytaradi
+func (e *env) wait(jobs []*services.Job) []resultView {
+	var mu sync.Mutex // Bir nechta ishchi bir vaqtda xabar chiqarmasligi uchun
+	for _, job := range jobs {
+		var once sync.Once
+		job.Watch(func(info services.JobInfo) {
+			if info.State == services.JobRunning && !e.json {
+				once.Do(func() {
+					mu.Lock()
+					fmt.Fprintf(e.stderr, "%s: %s...\n", info.Kind, strings.TrimSpace(info.Software.Name+" "+info.Software.Version))
+					mu.Unlock()
+				})
+			}
+		})
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-e.ctx.Done(): // Ctrl+C: yuklashlar to‘xtatiladi, o‘rnatilganlar avvalgi holatiga qaytadi
+			for _, job := range jobs {
+				job.Cancel()
+			}
+		case <-done:
+		}
+	}()
+	batch := services.WaitJobs(jobs)
+	close(done)
+
+	var results []resultView
+	for _, info := range batch.Done {
+		results = append(results, resultView{ID: info.Software.ID, Name: info.Software.Name, Action: jobAction(info.Kind), Version: info.Installed.Version, OK: true})
+	}
+	for _, info := range batch.Failed {
+		r := failed(info.Software.ID, jobAction(info.Kind), info.Err)
+		r.Name = info.Software.Name
+		results = append(results, r)
+	}
+	return results
+}
+
+// report - natijalarni va xulosani chiqaradi.
+// Chiqish kodi - birinchi muvaffaqiyatsiz natijaning kodi.
+func (e *env) report(results []resultView) int {
+	code := ExitOK
+	for _, r := range results {
+		if code == ExitOK && r.code != ExitOK {
+			code = r.code
+		}
+	}
+	if e.json {
+		if results == nil {
+			results = []resultView{} // JSON da null emas, bo‘sh ro‘yxat
+		}
+		e.printJSON(results)
+		return code
+	}
+	ok, bad := 0, 0
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			fmt.Fprintf(e.stdout, "  - %s %s: %s\n", name(r), r.Version, r.Message)
+		case r.OK:
+			ok++
+			line := strings.TrimSpace(name(r) + " " + r.Version)
+			if r.Message != "" { // Masalan sozlash o‘zgarishlari
+				line += ": " + r.Message
+			}
+			fmt.Fprintf(e.stdout, "  ✓ %s\n", line)
+		default:
+			bad++
+			fmt.Fprintf(e.stdout, "  ✗ %s: %s\n", name(r), r.Error)
+		}
+	}
+	if len(results) > 1 {
+		fmt.Fprintf(e.stdout, "Muvaffaqiyatli: %d, xatolik: %d\n", ok, bad)
+	}
+	return code
+}
+
+// jobAction - vazifa turining natijadagi nomi
+func jobAction(kind services.JobKind) string {
+	switch kind {
+	case services.JobUpdate:
+		return "update"
+	case services.JobRollback:
+		return "rollback"
+	}
+	return "install"
+}
+
+func find(softwares []models.Software, id string) (models.Software, bool) {
+	for _, software := range softwares {
+		if software.ID == id {
+			return software, true
+		}
+	}
+	return models.Software{}, false
+}
+
+func hasRecord(records map[string]models.DownloadedSoftware, id string) bool {
+	_, ok := records[id]
+	return ok
+}
+
+func failed(id, action string, err error) resultView {
+	return resultView{ID: id, Action: action, Error: err.Error(), code: exitCode(err)}
+}
+
+func skipped(id, name, action, ver, reason string) resultView {
+	return resultView{ID: id, Name: name, Action: action, Version: ver, OK: true, Skipped: true, Message: reason}
+}
+
+func name(r resultView) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.ID
+}
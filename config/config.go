@@ -0,0 +1,348 @@
+package config
+
+import (
+	"encoding/json" // Sozlamalar faylini o‘qish uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"flag"          // Buyruq qatori bayroqlarini o‘qish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"main/models"   // Reliz kanallari
+	"net/url"       // URL larni yig‘ish uchun
+	"os"            // Fayl tizimi va muhit o‘zgaruvchilari bilan ishlash uchun
+	"path/filepath" // Fayl yo‘llarini boshqarish uchun
+	"strconv"       // Sonlarni o‘qish uchun
+	"strings"       // Satrlar bilan ishlash uchun
+	"time"          // Vaqt oraliqlari uchun
+)
+
+// Sozlamalardagi xatoliklar
+var (
+	ErrConfigRead  = errors.New("sozlamalar faylini o'qishda xatolik") // Faylni ochib yoki dekodlab bo‘lmadi
+	ErrInvalidFlag = errors.New("noto'g'ri parametr")                  // Bayroq yoki muhit o‘zgaruvchisi noto‘g‘ri
+)
+
+// Muhit o‘zgaruvchilari (fayldagi qiymatlarni qayta yozadi, bayroqlar esa ularni)
+const (
+	EnvConfigFile      = "APPSTORE_CONFIG"           // Sozlamalar fayli yo‘li
+	EnvBaseURL         = "APPSTORE_BASE_URL"         // Katalog serverining asosiy URL i
+	EnvTimeout         = "APPSTORE_TIMEOUT"          // Katalog so‘rovlari uchun vaqt chegarasi
+	EnvDownloadTimeout = "APPSTORE_DOWNLOAD_TIMEOUT" // Paket yuklash uchun vaqt chegarasi
+	EnvInstallRoot     = "APPSTORE_INSTALL_ROOT"     // Dasturlar o‘rnatiladigan papka
+	EnvRetries         = "APPSTORE_RETRIES"          // 5xx va tarmoq xatoliklarida qayta urinishlar soni
+	EnvWorkers         = "APPSTORE_WORKERS"          // Bir vaqtda ishlaydigan yuklashlar soni
+	EnvKeepVersions    = "APPSTORE_KEEP_VERSIONS"    // Diskda saqlanadigan avvalgi versiyalar soni
+	EnvChannel         = "APPSTORE_CHANNEL"          // Umumiy reliz kanali (stable, beta, nightly)
+	EnvCheckInterval   = "APPSTORE_CHECK_INTERVAL"   // Yangilanishlarni fonda tekshirish oralig‘i
+	EnvKeysFile        = "APPSTORE_KEYS_FILE"        // Ishonchli nashriyotchi kalitlari fayli
+)
+
+// Standart qiymatlar
+const (
+	DefaultBaseURL         = "http://localhost:8080"
+	DefaultTimeout         = 30 * time.Second
+	DefaultDownloadTimeout = 30 * time.Minute
+	DefaultRetries         = 3
+	DefaultWorkers         = 2
+	DefaultKeepVersions    = 1
+	DefaultCheckInterval   = 6 * time.Hour
+)
+
+// Duration - JSON da "30s", "5m" ko‘rinishida yoziladigan vaqt oralig‘i
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil { // Satr bo‘lmasa, soniyalar soni deb qabul qiladi
+		var seconds float64
+		if err := json.Unmarshal(data, &seconds); err != nil {
+			return err
+		}
+		*d = Duration(seconds * float64(time.Second))
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// DefaultSourceName - alohida manbalar ko‘rsatilmaganda asosiy katalog nomi
+const DefaultSourceName = "default"
+
+// Source - bitta katalog manbasi (repozitoriy)
+type Source struct {
+	Name     string `json:"name"`     // Manba nomi (dasturlarda va ro‘yxatda saqlanadi)
+	BaseURL  string `json:"baseUrl"`  // Manba serverining asosiy URL i
+	Priority int    `json:"priority"` // Bir xil ID li dasturlar to‘qnashganda kattasi ustun (tengida ro‘yxatdagi birinchisi)
+}
+
+// CatalogURL - manbadagi dasturlar ro‘yxatini olish URL i (stable dan boshqa kanal ?channel= bilan so‘raladi)
+func (s Source) CatalogURL(channel string) string {
+	return s.BaseURL + "/appStore/getAllSoftware" + channelQuery(channel)
+}
+
+// DownloadURL - manbadan dastur paketini berilgan kanaldan yuklash URL i
+func (s Source) DownloadURL(id, channel string) string {
+	return s.BaseURL + "/appStore/download/" + url.PathEscape(id) + channelQuery(channel)
+}
+
+// channelQuery - stable kanal uchun bo‘sh (eski serverlar bilan moslik), qolganlari uchun "?channel=..."
+func channelQuery(channel string) string {
+	channel = models.NormalizeChannel(channel)
+	if channel == models.ChannelStable {
+		return ""
+	}
+	return "?channel=" + url.QueryEscape(channel)
+}
+
+// Config - dastur sozlamalari
+type Config struct {
+	BaseURL         string   `json:"baseUrl"`         // Katalog serverining asosiy URL i (masalan http://localhost:8080)
+	Sources         []Source `json:"sources"`         // Bir nechta katalog manbalari (bo‘sh bo‘lsa, faqat BaseURL ishlatiladi)
+	Timeout         Duration `json:"timeout"`         // Katalog so‘rovlari uchun vaqt chegarasi
+	DownloadTimeout Duration `json:"downloadTimeout"` // Bitta paketni yuklash uchun vaqt chegarasi
+	InstallRoot     string   `json:"installRoot"`     // Dasturlar o‘rnatiladigan papka (bo‘sh bo‘lsa, tizimga mos standart: Linux da $XDG_DATA_HOME/appstore)
+	Retries         int      `json:"retries"`         // 5xx va tarmoq xatoliklarida qayta urinishlar soni
+	Workers         int      `json:"workers"`         // Yuklash navbatida bir vaqtda ishlaydigan vazifalar soni
+	KeepVersions    int      `json:"keepVersions"`    // Orqaga qaytarish uchun saqlanadigan avvalgi versiyalar soni (0 - saqlanmaydi)
+	Channel         string   `json:"channel"`         // Umumiy reliz kanali (dastur uchun alohida tanlanmagan bo‘lsa)
+	CheckInterval   Duration `json:"checkInterval"`   // Yangilanishlarni fonda tekshirish oralig‘i (0 - tekshirilmaydi)
+	KeysPath        string   `json:"keysPath"`        // Ishonchli kalitlar fayli (ko‘rsatilsa, majburiy: yo‘q yoki bo‘sh bo‘lsa, o‘rnatilmaydi)
+
+	Path string `json:"-"` // Sozlamalar o‘qilgan fayl yo‘li
+}
+
+// Default - standart sozlamalarni qaytaradi
+func Default() *Config {
+	return &Config{
+		BaseURL:         DefaultBaseURL,
+		Timeout:         Duration(DefaultTimeout),
+		DownloadTimeout: Duration(DefaultDownloadTimeout),
+		Retries:         DefaultRetries,
+		Workers:         DefaultWorkers,
+		KeepVersions:    DefaultKeepVersions,
+		Channel:         models.ChannelStable,
+		CheckInterval:   Duration(DefaultCheckInterval),
+	}
+}
+
+// DefaultPath - foydalanuvchi sozlamalar papkasidagi standart fayl yo‘li.
+// Papka aniqlanmasa, xatolik qaytariladi: joriy papkadagi config.json o‘qilmaydi.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir() // Masalan %APPDATA% yoki ~/.config
+	if err != nil {
+		return "", fmt.Errorf("%w: sozlamalar papkasi aniqlanmadi - %v", ErrConfigRead, err)
+	}
+	return filepath.Join(dir, "appStore", "config.json"), nil
+}
+
+// Load - sozlamalarni ketma-ket fayl, muhit o‘zgaruvchilari va bayroqlardan yig‘adi.
+// Bayroqlardan keyin qolgan argumentlarni (masalan buyruq nomini) qaytaradi.
+func Load(args []string) (*Config, []string, error) {
+	fs := flag.NewFlagSet("appStore", flag.ContinueOnError) // Bayroqlar to‘plami
+	configPath := fs.String("config", "", "sozlamalar fayli yo'li")
+	baseURL := fs.String("base-url", "", "katalog serverining asosiy URL i")
+	timeout := fs.Duration("timeout", 0, "katalog so'rovlari uchun vaqt chegarasi (masalan 30s)")
+	downloadTimeout := fs.Duration("download-timeout", 0, "paket yuklash uchun vaqt chegarasi (masalan 30m)")
+	installRoot := fs.String("install-root", "", "dasturlar o'rnatiladigan papka")
+	retries := fs.Int("retries", 0, "5xx va tarmoq xatoliklarida qayta urinishlar soni")
+	workers := fs.Int("workers", 0, "bir vaqtda ishlaydigan yuklashlar soni")
+	keepVersions := fs.Int("keep-versions", 0, "orqaga qaytarish uchun saqlanadigan avvalgi versiyalar soni")
+	channel := fs.String("channel", "", "umumiy reliz kanali (stable, beta, nightly)")
+	checkInterval := fs.Duration("check-interval", 0, "yangilanishlarni fonda tekshirish oralig'i (masalan 6h, 0 - o'chirilgan)")
+	keysPath := fs.String("keys", "", "ishonchli nashriyotchi kalitlari fayli (ko'rsatilsa, majburiy)")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFlag, err)
+	}
+
+	// 1. Fayl yo‘li: bayroq > muhit o‘zgaruvchisi > standart
+	path := *configPath
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	explicit := path != "" // Foydalanuvchi faylni aniq ko‘rsatganmi
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil { // Fayl yo‘li bayroq yoki APPSTORE_CONFIG orqali berilishi mumkin
+			return nil, nil, err
+		}
+	}
+
+	cfg := Default()
+	if err := cfg.readFile(path, explicit); err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Muhit o‘zgaruvchilari
+	if err := cfg.applyEnv(); err != nil {
+		return nil, nil, err
+	}
+
+	// 3. Bayroqlar (faqat ko‘rsatilganlari)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "base-url":
+			cfg.BaseURL = *baseURL
+			cfg.Sources = nil // Aniq ko‘rsatilgan URL fayldagi manbalar o‘rniga ishlatiladi
+		case "timeout":
+			cfg.Timeout = Duration(*timeout)
+		case "download-timeout":
+			cfg.DownloadTimeout = Duration(*downloadTimeout)
+		case "install-root":
+			cfg.InstallRoot = *installRoot
+		case "retries":
+			cfg.Retries = *retries
+		case "workers":
+			cfg.Workers = *workers
+		case "keep-versions":
+			cfg.KeepVersions = *keepVersions
+		case "channel":
+			cfg.Channel = *channel
+		case "check-interval":
+			cfg.CheckInterval = Duration(*checkInterval)
+		case "keys":
+			cfg.KeysPath = *keysPath
+		}
+	})
+
+	if err := cfg.validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// validate - URL larni tozalaydi va manbalar to‘g‘riligini tekshiradi
+func (c *Config) validate() error {
+	if c.Retries < 0 {
+		return fmt.Errorf("%w: retries = %d", ErrInvalidFlag, c.Retries)
+	}
+	if c.Workers < 1 {
+		return fmt.Errorf("%w: workers = %d", ErrInvalidFlag, c.Workers)
+	}
+	if c.KeepVersions < 0 {
+		return fmt.Errorf("%w: keep-versions = %d", ErrInvalidFlag, c.KeepVersions)
+	}
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("%w: check-interval = %s", ErrInvalidFlag, time.Duration(c.CheckInterval))
+	}
+	c.Channel = models.NormalizeChannel(c.Channel)
+	if models.ChannelRank(c.Channel) < 0 {
+		return fmt.Errorf("%w: channel = %q", ErrInvalidFlag, c.Channel)
+	}
+	c.BaseURL = strings.TrimRight(c.BaseURL, "/") // Oxiridagi "/" ni olib tashlaydi
+	if _, err := url.Parse(c.BaseURL); err != nil || c.BaseURL == "" {
+		return fmt.Errorf("%w: base-url = %q", ErrInvalidFlag, c.BaseURL)
+	}
+
+	names := make(map[string]bool) // Manba nomlari takrorlanmasligi uchun
+	for i := range c.Sources {
+		src := &c.Sources[i]
+		src.BaseURL = strings.TrimRight(src.BaseURL, "/")
+		if src.Name == "" || names[src.Name] {
+			return fmt.Errorf("%w: manba nomi bo'sh yoki takrorlangan: %q", ErrInvalidFlag, src.Name)
+		}
+		if _, err := url.Parse(src.BaseURL); err != nil || src.BaseURL == "" {
+			return fmt.Errorf("%w: %s manbasi URL i = %q", ErrInvalidFlag, src.Name, src.BaseURL)
+		}
+		names[src.Name] = true
+	}
+	return nil
+}
+
+// readFile - JSON sozlamalar faylini o‘qiydi; standart fayl mavjud bo‘lmasa, xatolik emas
+func (c *Config) readFile(path string, explicit bool) error {
+	c.Path = path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return nil // Standart fayl hali yaratilmagan
+		}
+		return fmt.Errorf("%w: %s - %v", ErrConfigRead, path, err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrConfigRead, path, err)
+	}
+	return nil
+}
+
+// applyEnv - muhit o‘zgaruvchilaridagi qiymatlarni qo‘llaydi
+func (c *Config) applyEnv() error {
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		c.BaseURL = v
+		c.Sources = nil // --base-url kabi: aniq ko‘rsatilgan URL fayldagi manbalar o‘rniga ishlatiladi
+	}
+	if v := os.Getenv(EnvInstallRoot); v != "" {
+		c.InstallRoot = v
+	}
+	if v := os.Getenv(EnvChannel); v != "" {
+		c.Channel = v
+	}
+	if v := os.Getenv(EnvKeysFile); v != "" {
+		c.KeysPath = v
+	}
+	if v := os.Getenv(EnvRetries); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%w: %s = %q", ErrInvalidFlag, EnvRetries, v)
+		}
+		c.Retries = n
+	}
+	if v := os.Getenv(EnvWorkers); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%w: %s = %q", ErrInvalidFlag, EnvWorkers, v)
+		}
+		c.Workers = n
+	}
+	if v := os.Getenv(EnvKeepVersions); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%w: %s = %q", ErrInvalidFlag, EnvKeepVersions, v)
+		}
+		c.KeepVersions = n
+	}
+	for env, target := range map[string]*Duration{EnvTimeout: &c.Timeout, EnvDownloadTimeout: &c.DownloadTimeout, EnvCheckInterval: &c.CheckInterval} {
+		v := os.Getenv(env)
+		if v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%w: %s = %q", ErrInvalidFlag, env, v)
+		}
+		*target = Duration(d)
+	}
+	return nil
+}
+
+// Catalogs - so‘raladigan katalog manbalari (alohida manbalar bo‘lmasa, BaseURL dagi yagona manba)
+func (c *Config) Catalogs() []Source {
+	if len(c.Sources) > 0 {
+		return c.Sources
+	}
+	return []Source{{Name: DefaultSourceName, BaseURL: c.BaseURL}}
+}
+
+// Source - nomi bo‘yicha manbani qaytaradi; topilmasa (masalan eski yozuvlarda), birinchi manbani
+func (c *Config) Source(name string) Source {
+	catalogs := c.Catalogs()
+	for _, src := range catalogs {
+		if src.Name == name {
+			return src
+		}
+	}
+	return catalogs[0]
+}
+
+// DownloadURL - dasturni uni ro‘yxatga kiritgan manbadan, berilgan kanaldan yuklash URL i
+func (c *Config) DownloadURL(source, id, channel string) string {
+	return c.Source(source).DownloadURL(id, channel)
+}
@@ -2,13 +2,47 @@ package main
 
 // Asosiy paket - dastur ishga tushadigan joy
 import (
-	UI "main/ui" // Loyihaning UI komponentlari paketi
+	"fmt"           // Xatolik xabarlarini chiqarish uchun
+	"main/cli"      // Oynasiz (buyruq qatori) rejim
+	"main/config"   // Sozlamalar (fayl, muhit o‘zgaruvchilari, bayroqlar)
+	"main/services" // HTTP mijoz va o‘rnatish navbatini sozlash uchun
+	UI "main/ui"    // Loyihaning UI komponentlari paketi
+	"os"            // Buyruq qatori argumentlari va chiqish kodi uchun
+	"time"          // Vaqt chegaralari uchun
 
 	"fyne.io/fyne/v2"     // Fyne GUI frameworkning asosiy paketi
 	"fyne.io/fyne/v2/app" // Fyne ilovasini boshqarish uchun
 )
 
 func main() {
+	// Sozlamalarni fayl, muhit o‘zgaruvchilari va bayroqlardan o‘qish
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Xatolik:", err)
+		os.Exit(2)
+	}
+	// Katalog serveri bilan ishlovchi HTTP mijozni sozlash
+	services.DefaultClient = services.NewClient(time.Duration(cfg.Timeout), time.Duration(cfg.DownloadTimeout))
+	services.DefaultClient.MaxRetries = cfg.Retries // Qayta urinishlar soni
+
+	// Bayroqlardan keyin buyruq berilgan bo‘lsa, oynasiz bajarib chiqadi
+	if len(args) > 0 {
+		os.Exit(cli.Run(cfg, args, os.Stdout, os.Stderr))
+	}
+
+	// Joriy papkadagi eski ro‘yxatni va ~/AppData/Local dagi dasturlarni yangi joylarga ko‘chirish
+	installer, err := services.NewInstaller(services.DefaultClient, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Xatolik:", err)
+		os.Exit(1)
+	}
+	for _, err := range installer.Migrate() {
+		fmt.Fprintln(os.Stderr, "Xatolik:", err)
+	}
+
+	// Barcha kartalar uchun umumiy o‘rnatish navbati
+	queue := services.NewQueue(installer, cfg.Workers)
+
 	// Yangi Fyne ilovasini "men-go-fyne" ID bilan yaratish
 	myApp := app.NewWithID("men-go-fyne")
 
@@ -19,7 +53,10 @@ func main() {
 	myWindow.Resize(fyne.NewSize(800, 500))
 
 	// UI ni sozlash funksiyasini chaqirish (ui paketidan)
-	UI.SetupUI(myWindow)
+	UI.SetupUI(myWindow, cfg, queue)
+
+	// Yangilanishlarni fonda tekshirish (oraliq sozlamalardagi checkInterval)
+	UI.StartUpdateChecker(myWindow, services.NewUpdater(queue, time.Duration(cfg.CheckInterval)))
 
 	// Oynani ko'rsatish va dasturni ishga tushirish
 	myWindow.ShowAndRun()
@@ -0,0 +1,42 @@
+package models
+
+import "strings"
+
+// Reliz kanallari (barqarorlik bo‘yicha tartiblangan)
+const (
+	ChannelStable  = "stable"  // Barqaror versiyalar (standart)
+	ChannelBeta    = "beta"    // Sinovchilar uchun beta versiyalar
+	ChannelNightly = "nightly" // Har kungi yig‘malar
+)
+
+// Channels - barcha kanallar, barqarorroqdan boshlab
+var Channels = []string{ChannelStable, ChannelBeta, ChannelNightly}
+
+// NormalizeChannel - kanal nomini kichik harflarga o‘tkazadi; bo‘sh nom "stable" deb olinadi
+func NormalizeChannel(channel string) string {
+	channel = strings.ToLower(strings.TrimSpace(channel))
+	if channel == "" {
+		return ChannelStable
+	}
+	return channel
+}
+
+// ChannelRank - kanalning tartib raqami (stable 0, beta 1, nightly 2); noma'lum kanal uchun -1
+func ChannelRank(channel string) int {
+	channel = NormalizeChannel(channel)
+	for i, c := range Channels {
+		if c == channel {
+			return i
+		}
+	}
+	return -1
+}
+
+// ChannelsUpTo - berilgan kanal va undan barqarorroq kanallar (masalan beta -> stable, beta)
+func ChannelsUpTo(channel string) []string {
+	rank := ChannelRank(channel)
+	if rank < 0 {
+		rank = 0
+	}
+	return Channels[:rank+1]
+}
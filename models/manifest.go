@@ -0,0 +1,24 @@
+package models
+
+// Manifest - kompyuterda bo‘lishi kerak bo‘lgan dasturlar to‘plami (YAML yoki JSON fayl)
+type Manifest struct {
+	Apps  []ManifestApp `json:"apps" yaml:"apps"`   // Dasturlar ro‘yxati
+	Prune bool          `json:"prune" yaml:"prune"` // Manifestda yo‘q o‘rnatilgan dasturlarni o‘chirish
+}
+
+// ManifestApp - manifestdagi bitta dastur
+type ManifestApp struct {
+	ID        string             `json:"id" yaml:"id"`                                   // Katalogdagi dastur ID si
+	Version   string             `json:"version,omitempty" yaml:"version,omitempty"`     // Qadalgan versiya (bo‘sh bo‘lsa, kanaldagi eng yangisi)
+	Channel   string             `json:"channel,omitempty" yaml:"channel,omitempty"`     // Reliz kanali (bo‘sh bo‘lsa, umumiy sozlama)
+	Policy    string             `json:"policy,omitempty" yaml:"policy,omitempty"`       // Yangilash siyosati: manual, notify yoki auto
+	Shortcuts *ManifestShortcuts `json:"shortcuts,omitempty" yaml:"shortcuts,omitempty"` // Yorliqlar (bo‘sh bo‘lsa, katalogdagi sozlama)
+	Absent    bool               `json:"absent,omitempty" yaml:"absent,omitempty"`       // Dastur o‘rnatilmagan bo‘lishi kerak
+}
+
+// ManifestShortcuts - dastur yorliqlari (ko‘rsatilmagan maydon katalogdagi qiymatni oladi)
+type ManifestShortcuts struct {
+	Desktop   *bool `json:"desktop,omitempty" yaml:"desktop,omitempty"`     // Ishchi stolidagi yorliq
+	StartMenu *bool `json:"startMenu,omitempty" yaml:"startMenu,omitempty"` // Start menyudagi yorliq
+	AutoStart *bool `json:"autoStart,omitempty" yaml:"autoStart,omitempty"` // Tizim bilan birga ishga tushish
+}
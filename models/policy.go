@@ -0,0 +1,33 @@
+package models
+
+import "strings"
+
+// Yangilash siyosatlari (dastur uchun ro‘yxatda saqlanadi)
+const (
+	PolicyManual = "manual" // Faqat foydalanuvchi yangilaydi, bildirishnoma yuborilmaydi
+	PolicyNotify = "notify" // Yangilanish haqida bildirishnoma yuboriladi (standart)
+	PolicyAuto   = "auto"   // Dastur ishlamayotganda fonda avtomatik yangilanadi
+)
+
+// Policies - barcha yangilash siyosatlari
+var Policies = []string{PolicyManual, PolicyNotify, PolicyAuto}
+
+// NormalizePolicy - siyosat nomini kichik harflarga o‘tkazadi; bo‘sh nom "notify" deb olinadi
+func NormalizePolicy(policy string) string {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		return PolicyNotify
+	}
+	return policy
+}
+
+// ValidPolicy - siyosat ma'lummi
+func ValidPolicy(policy string) bool {
+	policy = NormalizePolicy(policy)
+	for _, p := range Policies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
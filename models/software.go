@@ -2,15 +2,20 @@ package models
 
 // Dastur ma'lumotlari uchun struktura (API dan keladi)
 type Software struct {
-	ID          string `json:"id"`          // Dastur identifikatori
-	Name        string `json:"name"`        // Dastur nomi
-	Description string `json:"description"` // Dastur tavsifi
-	Version     string `json:"version"`     // Dastur versiyasi
-	MainFile    string `json:"mainFile"`    // Asosiy fayl nomi
-	Icon        string `json:"icon"`        // Base64 kodlangan ikonka
-	IsDesktop   bool   `json:"isDesktop"`
-	IsStartup   bool   `json:"isStartup"`
-	IsAutoStart bool   `json:"isAutoStart"`
+	ID          string   `json:"id"`             // Dastur identifikatori
+	Name        string   `json:"name"`           // Dastur nomi
+	Description string   `json:"description"`    // Dastur tavsifi
+	Version     string   `json:"version"`        // Dastur versiyasi
+	MainFile    string   `json:"mainFile"`       // Asosiy fayl nomi
+	Args        []string `json:"args,omitempty"` // Asosiy faylni ishga tushirish argumentlari (yorliqlarga yoziladi)
+	Icon        string   `json:"icon"`           // Base64 kodlangan ikonka
+	SHA256      string   `json:"sha256"`         // Paket (ZIP) ning SHA-256 nazorat yig‘indisi (hex)
+	Size        int64    `json:"size"`           // Paket hajmi (bayt)
+	Source      string   `json:"source"`         // Dasturni ro‘yxatga kiritgan katalog manbasi (klient tomonida to‘ldiriladi)
+	Channel     string   `json:"channel"`        // Reliz kanali: stable, beta yoki nightly (bo‘sh bo‘lsa so‘ralgan kanal)
+	IsDesktop   bool     `json:"isDesktop"`
+	IsStartup   bool     `json:"isStartup"`
+	IsAutoStart bool     `json:"isAutoStart"`
 }
 
 // API javobini saqlash uchun struktura
@@ -21,14 +26,41 @@ type ResponseData struct {
 
 // Yuklangan dastur ma'lumotlari uchun struktura
 type DownloadedSoftware struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	Version      string `json:"version"`
-	DirPath      string `json:"dir_path"`
-	MainFile     string `json:"main_file"`
-	IconPath     string `json:"icon_path"` // Yangi maydon
-	DownloadDate string `json:"download_date"`
-	IsDesktop    bool   `json:"isDesktop"`
-	IsStartup    bool   `json:"isStartup"`
-	IsAutoStart  bool   `json:"isAutoStart"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	DirPath      string   `json:"dir_path"`
+	MainFile     string   `json:"main_file"`
+	Args         []string `json:"args,omitempty"`          // Ishga tushirish argumentlari
+	IconPath     string   `json:"icon_path"`               // Yangi maydon
+	Description  string   `json:"description,omitempty"`   // Dastur tavsifi (yorliq izohi sifatida)
+	Publisher    string   `json:"publisher,omitempty"`     // Imzosi tasdiqlangan nashriyotchi
+	Source       string   `json:"source,omitempty"`        // Dastur o‘rnatilgan katalog manbasi
+	Channel      string   `json:"channel,omitempty"`       // Foydalanuvchi tanlagan kanal (bo‘sh bo‘lsa, umumiy sozlama)
+	UpdatePolicy string   `json:"update_policy,omitempty"` // Yangilash siyosati: manual, notify yoki auto (bo‘sh bo‘lsa notify)
+	DownloadDate string   `json:"download_date"`
+	IsDesktop    bool     `json:"isDesktop"`
+	IsStartup    bool     `json:"isStartup"`
+	IsAutoStart  bool     `json:"isAutoStart"`
+
+	Previous []InstalledVersion `json:"previous,omitempty"` // Diskda saqlab qolingan avvalgi versiyalar (eng yangisi birinchi)
+}
+
+// Orqaga qaytarish uchun diskda saqlab qolingan avvalgi versiya
+type InstalledVersion struct {
+	Version      string   `json:"version"`
+	DirPath      string   `json:"dir_path"` // Versiya fayllari turgan papka
+	MainFile     string   `json:"main_file"`
+	Args         []string `json:"args,omitempty"`
+	IconPath     string   `json:"icon_path"`
+	Publisher    string   `json:"publisher,omitempty"`
+	Source       string   `json:"source,omitempty"`
+	DownloadDate string   `json:"download_date"`
+}
+
+// Ishonchli nashriyotchi ochiq kaliti (paket imzolarini tekshirish uchun)
+type PublisherKey struct {
+	ID        string `json:"id"`        // Kalit identifikatori (paketdagi keyId bilan mos keladi)
+	Publisher string `json:"publisher"` // Nashriyotchi nomi
+	PublicKey string `json:"publicKey"` // Base64 kodlangan Ed25519 ochiq kaliti (32 bayt)
 }
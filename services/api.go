@@ -1,17 +1,18 @@
 package services
 
 import (
-	"archive/zip" // ZIP arxivlar bilan ishlash uchun
-	"bytes"
+	"archive/zip"     // ZIP arxivlar bilan ishlash uchun
+	"context"         // So‘rovlarni bekor qilish uchun
 	"encoding/base64" // Base64 kodlash/dekodlash uchun
 	"encoding/json"   // JSON bilan ishlash uchun
 	"fmt"             // Formatlash va xatoliklarni chop etish uchun
 	"io"              // Fayl o‘qish/yozish uchun
 	"main/models"     // `models` paketidagi tuzilmalarni ishlatish uchun
+	"mime"            // Content-Type sarlavhasini tahlil qilish uchun
 	"net/http"        // HTTP so‘rovlar uchun
 	"os"              // Operatsion tizim bilan ishlash uchun (fayllar, papkalar)
-	"os/exec"         // Tashqi buyruqlarni ishga tushirish uchun
 	"path/filepath"   // Fayl yo‘llarini boshqarish uchun
+	"strings"         // Satrlar bilan ishlash uchun
 )
 
 // Yuklash javobi uchun tuzilma
@@ -23,152 +24,328 @@ type DownloadResponse struct {
 	Version     string `json:"version"`     // Dastur versiyasi
 	Icon        string `json:"icon"`        // Base64 kodlangan ikonka
 	File        string `json:"file"`        // Base64 kodlangan ZIP fayl
+	SHA256      string `json:"sha256"`      // ZIP faylning SHA-256 nazorat yig‘indisi (hex)
+	Size        int64  `json:"size"`        // ZIP fayl hajmi (bayt)
+	Signature   string `json:"signature"`   // Arxiv SHA-256 xeshi ustidan qo‘yilgan Ed25519 imzo (base64)
+	KeyID       string `json:"keyId"`       // Imzolagan nashriyotchi kalitining ID si
 	IsDesktop   bool   `json:"isDesktop"`
 	IsStartup   bool   `json:"isStartup"`
 	IsAutoStart bool   `json:"isAutoStart"`
 }
 
-var DownloadPath = "C:/Downloads" // Standart yuklash yo‘li (o‘zgaruvchi)
+// Oqimli (streaming) yuklashda metama'lumotlar keladigan HTTP sarlavhalari
+const (
+	HeaderSoftwareID       = "X-Software-Id"        // Dastur ID si
+	HeaderSoftwareName     = "X-Software-Name"      // Dastur nomi
+	HeaderSoftwareVersion  = "X-Software-Version"   // Dastur versiyasi
+	HeaderSoftwareMainFile = "X-Software-Main-File" // Asosiy ishga tushiriladigan fayl
+	HeaderSoftwareIcon     = "X-Software-Icon"      // Base64 kodlangan ikonka (ixtiyoriy)
+	HeaderSoftwareSHA256   = "X-Software-Sha256"    // ZIP faylning SHA-256 nazorat yig‘indisi (hex)
+	HeaderSoftwareSig      = "X-Software-Signature" // Ed25519 imzo (base64)
+	HeaderSoftwareKeyID    = "X-Software-Key-Id"    // Imzolagan kalit ID si
+)
+
+// DownloadOptions - yuklashni sozlash uchun qo‘shimcha parametrlar
+type DownloadOptions struct {
+	SHA256 string // Katalogdagi kutilgan SHA-256 nazorat yig‘indisi (bo‘sh bo‘lsa, faqat server javobidagisi tekshiriladi)
+	Size   int64  // Katalogdagi kutilgan hajm (0 bo‘lsa, tekshirilmaydi)
+
+	TrustStore *TrustStore // Ishonchli nashriyotchi kalitlari (bo‘sh bo‘lmasa, imzo majburiy)
+
+	OnProgress ProgressFunc // Yuklash va ochish holati haqida xabar olish uchun (ixtiyoriy)
+
+	// PartialPath - uzilgan yuklashni davom ettirish uchun ".part" fayl yo‘li (bo‘sh bo‘lsa, davom ettirilmaydi).
+	// dirPath bilan bir disk bo‘limida bo‘lishi kerak, chunki tugagach u ko‘chiriladi.
+	PartialPath string
+}
 
-// Faylni va ikonani URL dan yuklab olish va ZIP ni ochish funksiyasi
-func DownloadFile(url, dirPath string) (mainFilePath, iconFilePath string, err error) {
-	resp, err := http.Get(url) // URL ga HTTP GET so‘rov yuboradi
-	if err != nil {            // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("HTTP so'rovda xatolik: %v", err) // Xatolik xabarini qaytaradi
+// MetadataSuffix - sarlavhalarda metama'lumot bo‘lmasa, yuklash URL iga qo‘shiladigan alohida endpoint
+const MetadataSuffix = "/metadata"
+
+// DownloadResult - muvaffaqiyatli yuklash natijasi
+type DownloadResult struct {
+	MainFilePath string // Asosiy ishga tushiriladigan faylning to‘liq yo‘li
+	IconFilePath string // Saqlangan ikonka faylining to‘liq yo‘li
+	Publisher    string // Imzo tasdiqlangan nashriyotchi nomi (imzosiz paketda bo‘sh)
+}
+
+// Faylni va ikonani URL dan yuklab olish va ZIP ni ochish funksiyasi (standart mijoz bilan).
+func DownloadFile(url, dirPath string, opts DownloadOptions) (*DownloadResult, error) {
+	return DefaultClient.DownloadFile(context.Background(), url, dirPath, opts)
+}
+
+// DownloadFile - faylni va ikonani URL dan yuklab oladi va ZIP ni ochadi.
+// Server xom ZIP baytlarini (application/zip yoki application/octet-stream) qaytarsa, ular to‘g‘ridan-to‘g‘ri
+// diskka oqim bilan yoziladi; aks holda eski JSON (base64) formati ishlatiladi.
+// Arxiv ochilishidan oldin uning SHA-256 yig‘indisi, hajmi va nashriyotchi imzosi tekshiriladi.
+// opts.PartialPath berilsa, uzilgan yuklash Range/If-Range orqali davom ettiriladi.
+// ctx bekor qilinsa yoki DownloadTimeout tugasa, yuklash ErrCanceled / ErrTimeout bilan to‘xtaydi.
+func (c *Client) DownloadFile(ctx context.Context, url, dirPath string, opts DownloadOptions) (*DownloadResult, error) {
+	ctx, cancel := withTimeout(ctx, c.DownloadTimeout) // Butun yuklash uchun vaqt chegarasi
+	defer cancel()
+
+	var offset int64       // ".part" fayldagi mavjud baytlar
+	var header http.Header // Davom ettirish sarlavhalari
+	if opts.PartialPath != "" {
+		offset, header = resumeHeader(opts.PartialPath, url)
 	}
-	defer resp.Body.Close() // Funksiya tugagach, javobni yopadi
 
-	if resp.StatusCode != http.StatusOK { // Agar serverdan 200 OK bo‘lmasa
-		return "", "", fmt.Errorf("serverdan noto'g'ri javob: %s", resp.Status) // Xatolik xabarini qaytaradi
+	resp, err := c.get(ctx, url, header) // URL ga HTTP GET so‘rov yuboradi
+	if err != nil {                      // Agar xatolik bo‘lsa
+		return nil, err // Turlangan xatolikni qaytaradi
 	}
+	defer resp.Body.Close() // Funksiya tugagach, javobni yopadi
 
-	var downloadResp DownloadResponse                      // Yuklash javobi uchun o‘zgaruvchi
-	err = json.NewDecoder(resp.Body).Decode(&downloadResp) // JSON ni dekod qiladi
-	if err != nil {                                        // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("JSON dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 { // ".part" serverdagi fayldan katta
+		resp.Body.Close()
+		RemovePartial(opts.PartialPath)  // Qismni tashlaydi
+		opts.PartialPath, offset = "", 0 // Boshidan faqat bir marta qayta yuklanadi: Range va ".part" siz
+		resp, err = c.get(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
 	}
 
-	fileData, err := base64.StdEncoding.DecodeString(downloadResp.File) // ZIP faylni Base64 dan dekod qiladi
-	if err != nil {                                                     // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("Base64 fayl dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	total := resp.ContentLength // Jami hajm (noma'lum bo‘lsa -1)
+	switch {
+	case resp.StatusCode == http.StatusOK: // To‘liq javob (server Range ni e'tiborsiz qoldirdi yoki fayl o‘zgargan)
+		offset = 0
+	case resp.StatusCode == http.StatusPartialContent && offset > 0: // Qolgan qism
+		start, rangeTotal, err := contentRange(resp)
+		if err != nil || start != offset {
+			RemovePartial(opts.PartialPath) // Keyingi urinish boshidan boshlanadi
+			if err == nil {
+				err = fmt.Errorf("%w: kutilgan %d, olingan %d", ErrBadContentRange, offset, start)
+			}
+			return nil, err
+		}
+		total = rangeTotal
+	default: // Agar serverdan 200 OK bo‘lmasa
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status} // Xatolik xabarini qaytaradi
 	}
 
-	zipFileName := downloadResp.Name + ".zip"          // ZIP fayl nomi (dastur nomi + .zip)
-	zipFilePath := filepath.Join(dirPath, zipFileName) // ZIP faylning to‘liq yo‘li
+	var downloadResp DownloadResponse // Yuklash javobi (metama'lumotlar) uchun o‘zgaruvchi
+	var zipFilePath string            // Diskka yozilgan ZIP fayl yo‘li
+
+	body := &progressReader{r: resp.Body, onProgress: opts.OnProgress, received: offset, total: total} // Olingan baytlarni sanaydi
+	opts.OnProgress.report(Progress{Stage: StageDownload, BytesReceived: offset, TotalBytes: total})   // Yuklash boshlandi
+
+	if isArchiveContentType(resp.Header.Get("Content-Type")) { // Server xom ZIP oqimini yuborgan bo‘lsa
+		downloadResp, err = c.metadataFromResponse(ctx, url, resp) // Metama'lumotlarni sarlavhalardan yoki alohida endpointdan oladi
+		if err != nil {                                            // Agar xatolik bo‘lsa
+			return nil, err // Xatolikni qaytaradi
+		}
+		if resp.StatusCode == http.StatusPartialContent { // Qisman javobda Content-Length faqat qolgan qism hajmi
+			downloadResp.Size = 0
+			if total > 0 {
+				downloadResp.Size = total
+			}
+		}
+		if opts.PartialPath != "" { // Uzilsa, davom ettirib bo‘ladigan tarzda yozadi
+			zipFilePath, err = savePartial(body, resp, url, opts.PartialPath, offset, dirPath, downloadResp.Name)
+		} else {
+			zipFilePath, err = saveArchiveStream(body, dirPath, downloadResp.Name) // ZIP ni oqim bilan diskka yozadi
+		}
+		if err != nil { // Agar xatolik bo‘lsa
+			if ctxErr := contextError(ctx, url); ctxErr != nil { // Bekor qilingan yoki vaqti tugagan
+				return nil, ctxErr
+			}
+			return nil, err // Xatolikni qaytaradi
+		}
+	} else { // Aks holda eski JSON formatidan foydalanadi
+		if resp.StatusCode == http.StatusPartialContent { // JSON javobni qismlab davom ettirib bo‘lmaydi
+			RemovePartial(opts.PartialPath)
+			return nil, fmt.Errorf("%w: JSON javob qisman keldi", ErrBadContentRange)
+		}
+		RemovePartial(opts.PartialPath)                   // JSON formatida ".part" ishlatilmaydi
+		err = json.NewDecoder(body).Decode(&downloadResp) // JSON ni dekod qiladi
+		if err != nil {                                   // Agar xatolik bo‘lsa
+			if ctxErr := contextError(ctx, url); ctxErr != nil { // Bekor qilingan yoki vaqti tugagan
+				return nil, ctxErr
+			}
+			return nil, fmt.Errorf("JSON dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+		}
+		fileData := base64.NewDecoder(base64.StdEncoding, strings.NewReader(downloadResp.File)) // Base64 ni bo‘laklab dekod qiladi
+		zipFilePath, err = saveArchiveStream(fileData, dirPath, downloadResp.Name)              // Dekodlangan ZIP ni diskka yozadi
+		downloadResp.File = ""                                                                  // Katta satrni xotiradan bo‘shatish uchun tozalaydi
+		if err != nil {                                                                         // Agar xatolik bo‘lsa
+			return nil, fmt.Errorf("Base64 fayl dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+		}
+	}
 
-	out, err := os.Create(zipFilePath) // ZIP faylni yaratadi
-	if err != nil {                    // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("ZIP fayl yaratishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	opts.OnProgress.report(Progress{Stage: StageVerify, BytesReceived: body.received, TotalBytes: total})
+	result := &DownloadResult{}                                            // Natija uchun tuzilma
+	result.Publisher, err = verifyArchive(zipFilePath, opts, downloadResp) // Ochishdan (va davom ettirilgan yuklashdan) keyin butunligi va imzosini tekshiradi
+	if err != nil {                                                        // Agar yig‘indi, hajm yoki imzo mos kelmasa
+		os.Remove(zipFilePath) // Buzilgan arxivni o‘chiradi
+		return nil, err        // Xatolikni qaytaradi
 	}
-	defer out.Close() // Funksiya tugagach, faylni yopadi
 
-	_, err = out.Write(fileData) // Dekodlangan ZIP ma’lumotlarini faylga yozadi
-	if err != nil {              // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("ZIP faylga yozishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	result.MainFilePath, err = extractZIP(ctx, zipFilePath, dirPath, downloadResp.MainFile, opts.OnProgress) // ZIP ni papkaga ochadi
+	if err != nil {                                                                                          // Agar xatolik bo‘lsa
+		return nil, err // Xatolikni qaytaradi
 	}
 
-	// ZIP faylni ochish
-	zipReader, err := zip.OpenReader(zipFilePath) // ZIP faylni o‘qish uchun ochadi
-	if err != nil {                               // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("ZIP faylni ochishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	result.IconFilePath, err = saveIcon(downloadResp.Icon, dirPath, downloadResp.Name) // Ikonkani saqlaydi
+	if err != nil {                                                                    // Agar xatolik bo‘lsa
+		return nil, err // Xatolikni qaytaradi
 	}
 
-	// ZIP ichidagi fayllarni chiqarish
-	for _, f := range zipReader.File { // ZIP ichidagi har bir fayl bo‘yicha tsikl
-		fPath := filepath.Join(dirPath, f.Name) // Faylning chiqariladigan yo‘li
-		if f.FileInfo().IsDir() {               // Agar bu papka bo‘lsa
-			os.MkdirAll(fPath, os.ModePerm) // Papkani yaratadi
-			continue                        // Keyingi faylga o‘tadi
-		}
+	return result, nil // Asosiy fayl va ikonka yo‘llarini qaytaradi
+}
 
-		rc, err := f.Open() // ZIP ichidagi faylni ochadi
-		if err != nil {     // Agar xatolik bo‘lsa
-			zipReader.Close()                                                          // ZIP o‘quvchini yopadi
-			return "", "", fmt.Errorf("ZIP ichidagi faylni ochishda xatolik: %v", err) // Xatolik xabarini qaytaradi
-		}
-		defer rc.Close() // Fayl o‘qish tugagach, yopadi
+// isArchiveContentType - javob xom ZIP oqimi ekanligini Content-Type bo‘yicha aniqlaydi
+func isArchiveContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType) // Parametrlarsiz media turini ajratadi
+	if err != nil {                                       // Agar sarlavha noto‘g‘ri bo‘lsa
+		return false // JSON deb hisoblaydi
+	}
+	switch mediaType {
+	case "application/zip", "application/x-zip-compressed", "application/octet-stream":
+		return true
+	}
+	return false
+}
 
-		outFile, err := os.Create(fPath) // Chiqariladigan faylni yaratadi
-		if err != nil {                  // Agar xatolik bo‘lsa
-			zipReader.Close()                                             // ZIP o‘quvchini yopadi
-			return "", "", fmt.Errorf("fayl yaratishda xatolik: %v", err) // Xatolik xabarini qaytaradi
-		}
-		defer outFile.Close() // Funksiya tugagach, faylni yopadi
+// metadataFromResponse - oqimli javob uchun metama'lumotlarni sarlavhalardan oladi.
+// Sarlavhalar yetarli bo‘lmasa, "<url>/metadata" endpointidan JSON ko‘rinishida so‘raydi.
+func (c *Client) metadataFromResponse(ctx context.Context, url string, resp *http.Response) (DownloadResponse, error) {
+	meta := DownloadResponse{ // Sarlavhalardan metama'lumotlarni yig‘adi
+		ID:        resp.Header.Get(HeaderSoftwareID),
+		Name:      resp.Header.Get(HeaderSoftwareName),
+		Version:   resp.Header.Get(HeaderSoftwareVersion),
+		MainFile:  resp.Header.Get(HeaderSoftwareMainFile),
+		Icon:      resp.Header.Get(HeaderSoftwareIcon),
+		SHA256:    resp.Header.Get(HeaderSoftwareSHA256),
+		Signature: resp.Header.Get(HeaderSoftwareSig),
+		KeyID:     resp.Header.Get(HeaderSoftwareKeyID),
+		Size:      resp.ContentLength, // Noma'lum bo‘lsa -1
+	}
+	if meta.Size < 0 {
+		meta.Size = 0
+	}
+	if meta.Name != "" { // Nom sarlavhada bo‘lsa, shuning o‘zi yetarli
+		return meta, nil
+	}
 
-		_, err = io.Copy(outFile, rc) // ZIP ichidagi faylni nusxalaydi
-		if err != nil {               // Agar xatolik bo‘lsa
-			zipReader.Close()                                              // ZIP o‘quvchini yopadi
-			return "", "", fmt.Errorf("faylni saqlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
-		}
+	metaCtx, cancel := withTimeout(ctx, c.Timeout) // Metama'lumot so‘rovi uchun qisqa vaqt chegarasi
+	defer cancel()
 
-		if f.Name == downloadResp.MainFile { // Agar bu asosiy fayl bo‘lsa
-			mainFilePath = fPath // Asosiy fayl yo‘lini saqlaydi
-		}
+	metaURL := url + MetadataSuffix               // Alohida metama'lumot endpointi
+	metaResp, err := c.get(metaCtx, metaURL, nil) // Endpointga so‘rov yuboradi
+	if err != nil {                               // Agar xatolik bo‘lsa
+		return meta, err // Turlangan xatolikni qaytaradi
 	}
+	defer metaResp.Body.Close() // Funksiya tugagach, javobni yopadi
 
-	// ZIP readerni yopish
-	err = zipReader.Close() // ZIP o‘quvchini yopadi
-	if err != nil {         // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("ZIP readerni yopishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	if metaResp.StatusCode != http.StatusOK { // Agar serverdan 200 OK bo‘lmasa
+		return meta, &StatusError{URL: metaURL, StatusCode: metaResp.StatusCode, Status: metaResp.Status} // Xatolik xabarini qaytaradi
+	}
+	if err := json.NewDecoder(metaResp.Body).Decode(&meta); err != nil { // JSON ni dekod qiladi
+		if ctxErr := contextError(metaCtx, metaURL); ctxErr != nil { // Bekor qilingan yoki vaqti tugagan
+			return meta, ctxErr
+		}
+		return meta, fmt.Errorf("metama'lumot JSON dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	}
+	if meta.Name == "" { // Nom baribir bo‘lmasa, fayl nomini yaratib bo‘lmaydi
+		return meta, fmt.Errorf("metama'lumotda dastur nomi yo'q: %s", url)
 	}
+	return meta, nil
+}
 
-	// Ikonka faylni dekodlash va saqlash
-	iconData, err := base64.StdEncoding.DecodeString(downloadResp.Icon) // Ikonkani Base64 dan dekod qiladi
-	if err != nil {                                                     // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("Base64 ikonka dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+// saveArchiveStream - ZIP ma'lumotlarini vaqtinchalik faylga oqim bilan yozadi va "<name>.zip" ga ko‘chiradi
+func saveArchiveStream(src io.Reader, dirPath, name string) (string, error) {
+	if err := validateFileName(name); err != nil { // Server bergan nom fayl nomi sifatida xavfsizligini tekshiradi
+		return "", err
+	}
+	tmp, err := os.CreateTemp(dirPath, name+"-*.zip.tmp") // Vaqtinchalik faylni yaratadi
+	if err != nil {                                       // Agar xatolik bo‘lsa
+		return "", fmt.Errorf("ZIP fayl yaratishda xatolik: %v", err) // Xatolik xabarini qaytaradi
 	}
+	tmpPath := tmp.Name() // Vaqtinchalik fayl yo‘li
 
-	iconFilePath = filepath.Join(dirPath, downloadResp.Name+".png") // Ikonka faylning yo‘li
-	iconOut, err := os.Create(iconFilePath)                         // Ikonka faylni yaratadi
-	if err != nil {                                                 // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("ikonka fayl yaratishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	_, err = io.Copy(tmp, src) // Ma'lumotlarni xotiraga to‘liq yuklamasdan faylga ko‘chiradi
+	closeErr := tmp.Close()    // Faylni yopadi
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil { // Agar yozishda xatolik bo‘lsa
+		os.Remove(tmpPath)                                            // Chala faylni o‘chiradi
+		return "", fmt.Errorf("ZIP faylga yozishda xatolik: %v", err) // Xatolik xabarini qaytaradi
 	}
-	defer iconOut.Close() // Funksiya tugagach, faylni yopadi
 
-	_, err = iconOut.Write(iconData) // Ikonka ma’lumotlarini faylga yozadi
-	if err != nil {                  // Agar xatolik bo‘lsa
-		return "", "", fmt.Errorf("ikonka faylga yozishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	zipFilePath := filepath.Join(dirPath, name+".zip")      // ZIP faylning to‘liq yo‘li (dastur nomi + .zip)
+	if err := os.Rename(tmpPath, zipFilePath); err != nil { // Vaqtinchalik faylni asl nomiga o‘zgartiradi
+		os.Remove(tmpPath)                                             // Vaqtinchalik faylni o‘chiradi
+		return "", fmt.Errorf("ZIP faylni saqlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
 	}
+	return zipFilePath, nil
+}
 
-	if mainFilePath == "" && len(zipReader.File) > 0 { // Agar asosiy fayl topilmasa va ZIP da fayllar bo‘lsa
-		mainFilePath = filepath.Join(dirPath, zipReader.File[0].Name) // Birinchi faylni asosiy deb oladi
+// saveIcon - Base64 kodlangan ikonkani "<name>.png" fayliga saqlaydi
+func saveIcon(icon, dirPath, name string) (string, error) {
+	if err := validateFileName(name); err != nil { // Server bergan nom fayl nomi sifatida xavfsizligini tekshiradi
+		return "", err
+	}
+	iconData, err := base64.StdEncoding.DecodeString(icon) // Ikonkani Base64 dan dekod qiladi
+	if err != nil {                                        // Agar xatolik bo‘lsa
+		return "", fmt.Errorf("Base64 ikonka dekodlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
 	}
 
-	return mainFilePath, iconFilePath, nil // Asosiy fayl va ikonka yo‘llarini qaytaradi
+	iconFilePath := filepath.Join(dirPath, name+".png")                // Ikonka faylning yo‘li
+	if err := os.WriteFile(iconFilePath, iconData, 0644); err != nil { // Ikonka ma’lumotlarini faylga yozadi
+		return "", fmt.Errorf("ikonka faylga yozishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	}
+	return iconFilePath, nil
 }
 
-// API dan dastur ma'lumotlarini olish funksiyasi
+// API dan dastur ma'lumotlarini olish funksiyasi (standart mijoz bilan)
 func FetchAPIData(url string) ([]models.Software, error) {
-	resp, err := http.Get(url) // URL ga HTTP GET so‘rov yuboradi
-	if err != nil {            // Agar xatolik bo‘lsa
+	return DefaultClient.FetchAPIData(context.Background(), url)
+}
+
+// FetchAPIData - katalog API sidan dasturlar ro‘yxatini oladi
+func (c *Client) FetchAPIData(ctx context.Context, url string) ([]models.Software, error) {
+	ctx, cancel := withTimeout(ctx, c.Timeout) // Katalog so‘rovi uchun vaqt chegarasi
+	defer cancel()
+
+	resp, err := c.get(ctx, url, nil) // URL ga HTTP GET so‘rov yuboradi
+	if err != nil {                   // Agar xatolik bo‘lsa
 		return nil, err // Xatolikni qaytaradi
 	}
 	defer resp.Body.Close() // Funksiya tugagach, javobni yopadi
 
+	if resp.StatusCode != http.StatusOK { // Agar serverdan 200 OK bo‘lmasa
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
 	var data models.ResponseData                   // Javob uchun tuzilma
 	err = json.NewDecoder(resp.Body).Decode(&data) // JSON ni dekod qiladi
 	if err != nil {                                // Agar xatolik bo‘lsa
-		return nil, err // Xatolikni qaytaradi
+		if ctxErr := contextError(ctx, url); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("JSON dekodlashda xatolik: %v", err) // Xatolikni qaytaradi
 	}
 
 	return data.Object, nil // Dasturlar ro‘yxatini qaytaradi
 }
 
-// Foydalanuvchi mahalliy papkasini olish
-func GetUserLocalPath() string {
-	homeDir, err := os.UserHomeDir() // Foydalanuvchi asosiy papkasini oladi
-	if err != nil {                  // Agar xatolik bo‘lsa
-		fmt.Println("Xatolik: Foydalanuvchi papkasi aniqlanmadi.") // Xatolikni konsolga chiqaradi
-		return "C:\\Users\\Default\\AppData\\Local"                // Standart yo‘lni qaytaradi
-	}
-	return filepath.Join(homeDir, "AppData", "Local") // Foydalanuvchi Local papkasini qaytaradi
+// Foydalanuvchi mahalliy papkasini olish: %LOCALAPPDATA% (mutlaq yo‘l bo‘lsa), aks holda ~/AppData/Local.
+// Uy papkasi aniqlanmasa, xatolik qaytariladi (boshqa foydalanuvchi papkasiga qaytilmaydi).
+func GetUserLocalPath() (string, error) {
+	return xdgDir("LOCALAPPDATA", "AppData", "Local")
 }
 
-// ZIP faylni Local papkaga ochish
+// ZIP faylni Local papkaga ochish (faqat birinchi faylni chiqaradi)
 func ExtractZIPToLocal(src string) error {
-	dest := GetUserLocalPath()            // Foydalanuvchi Local papkasini oladi
-	err := os.MkdirAll(dest, os.ModePerm) // Agar papka mavjud bo‘lmasa, yaratadi
-	if err != nil {                       // Agar xatolik bo‘lsa
+	dest, err := GetUserLocalPath() // Foydalanuvchi Local papkasini oladi
+	if err != nil {                 // Agar papka aniqlanmasa
+		return err // Xatolikni qaytaradi
+	}
+	err = os.MkdirAll(dest, os.ModePerm) // Agar papka mavjud bo‘lmasa, yaratadi
+	if err != nil {                      // Agar xatolik bo‘lsa
 		return err // Xatolikni qaytaradi
 	}
 
@@ -178,62 +355,16 @@ func ExtractZIPToLocal(src string) error {
 	}
 	defer r.Close() // Funksiya tugagach, ZIP ni yopadi
 
-	var extractedFilePath string // Chiqarilgan fayl yo‘li uchun o‘zgaruvchi
+	paths, err := validateZIPEntries(r.File, dest) // Barcha yozuvlarni oldindan tekshiradi
+	if err != nil {                                // Agar xavfli yozuv topilsa
+		return err // Hech narsa yozmasdan xatolikni qaytaradi
+	}
 
-	for _, file := range r.File { // ZIP ichidagi fayllar bo‘yicha tsikl
+	for i, file := range r.File { // ZIP ichidagi fayllar bo‘yicha tsikl
 		if file.FileInfo().IsDir() { // Agar bu papka bo‘lsa
 			continue // Keyingi faylga o‘tadi
 		}
-
-		// Asosiy faylni chiqarish
-		extractedFilePath = filepath.Join(dest, file.Name) // Fayl yo‘lini birlashtiradi
-		outFile, err := os.Create(extractedFilePath)       // Chiqariladigan faylni yaratadi
-		if err != nil {                                    // Agar xatolik bo‘lsa
-			return err // Xatolikni qaytaradi
-		}
-		defer outFile.Close() // Funksiya tugagach, faylni yopadi
-
-		rc, err := file.Open() // ZIP ichidagi faylni ochadi
-		if err != nil {        // Agar xatolik bo‘lsa
-			return err // Xatolikni qaytaradi
-		}
-		defer rc.Close() // Fayl o‘qish tugagach, yopadi
-
-		_, err = io.Copy(outFile, rc) // Faylni nusxalaydi
-		if err != nil {               // Agar xatolik bo‘lsa
-			return err // Xatolikni qaytaradi
-		}
-		break // Faqat birinchi faylni chiqarib, to‘xtaydi
-	}
-
-	return nil // Muvaffaqiyatli yakunlanadi
-}
-
-// createShortcutVBScript - VBScript orqali yorliq yaratish uchun umumiy funksiya
-func createShortcutVBScript(shortcutPath, targetPath string) error {
-	// VBScript kodini to‘g‘ri formatda tayyorlaymiz
-	shortcutScript := fmt.Sprintf(
-		"set WshShell = WScript.CreateObject(\"WScript.Shell\")\n"+
-			"set shortcut = WshShell.CreateShortcut(\"%s\")\n"+
-			"shortcut.TargetPath = \"%s\"\n"+
-			"shortcut.Save",
-		shortcutPath, targetPath)
-
-	tempScript := filepath.Join(os.Getenv("TEMP"), "create_shortcut.vbs") // Vaqtinchalik VBS fayl yo‘li
-	err := os.WriteFile(tempScript, []byte(shortcutScript), 0644)         // VBS skriptni faylga yozadi
-	if err != nil {                                                       // Agar xatolik bo‘lsa
-		return fmt.Errorf("faylga yozishda xatolik: %v", err) // Xatolikni qaytaradi
-	}
-	defer os.Remove(tempScript) // Funksiya tugagach vaqtinchalik faylni o‘chiradi
-
-	cmd := exec.Command("wscript", tempScript) // VBS skriptni ishga tushirish buyrug‘i
-	var out bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &out    // Standart chiqishni saqlash uchun
-	cmd.Stderr = &stderr // Standart xatolik chiqishini saqlash uchun
-	err = cmd.Run()      // Buyruqni bajaradi
-	if err != nil {      // Agar xatolik bo‘lsa
-		return fmt.Errorf("skriptni ishga tushirishda xatolik: %v, stderr: %s", err, stderr.String()) // Xatolik va stderr ni qaytaradi
+		return extractZIPEntry(context.Background(), file, paths[i]) // Faqat birinchi faylni chiqarib, to‘xtaydi
 	}
 
 	return nil // Muvaffaqiyatli yakunlanadi
@@ -241,72 +372,30 @@ func createShortcutVBScript(shortcutPath, targetPath string) error {
 
 // CreateDesktopShortcut - Ishchi stolga yorliq yaratadi
 func CreateDesktopShortcut(targetPath, appName string) error {
-	desktopPath := filepath.Join(os.Getenv("USERPROFILE"), "Desktop", appName+".lnk") // Ishchi stolda yorliq yo‘li
-	err := createShortcutVBScript(desktopPath, targetPath)                            // VBScript orqali yorliq yaratadi
-	if err != nil {                                                                   // Agar xatolik bo‘lsa
-		return fmt.Errorf("ishchi stolga yorliq yaratishda xatolik: %v", err) // Xatolikni qaytaradi
-	}
-	return nil // Muvaffaqiyatli yakunlanadi
+	return NewShortcutManager().Create(Shortcut{Location: ShortcutDesktop, Name: appName, Target: targetPath}) // Joriy tizimga mos yorliq
 }
 
-// CreateStartMenuShortcut - Start menyuga yorliq yaratadi
+// CreateStartMenuShortcut - Start menyuga (Linux da ilovalar menyusiga) yorliq yaratadi
 func CreateStartMenuShortcut(targetPath, appName string) error {
-	startMenuPath := filepath.Join(os.Getenv("APPDATA"), "Microsoft\\Windows\\Start Menu\\Programs", appName+".lnk") // Start menyuda yorliq yo‘li
-	err := createShortcutVBScript(startMenuPath, targetPath)                                                         // VBScript orqali yorliq yaratadi
-	if err != nil {                                                                                                  // Agar xatolik bo‘lsa
-		return fmt.Errorf("start menyuga yorliq yaratishda xatolik: %v", err) // Xatolikni qaytaradi
-	}
-	return nil // Muvaffaqiyatli yakunlanadi
+	return NewShortcutManager().Create(Shortcut{Location: ShortcutStartMenu, Name: appName, Target: targetPath}) // Joriy tizimga mos yorliq
 }
 
-// CreateStartupShortcut - Startup papkasiga yorliq yaratadi (Windows ishga tushganda avtomatik ishga tushish uchun)
+// CreateStartupShortcut - Startup papkasiga yorliq yaratadi (tizimga kirganda avtomatik ishga tushish uchun)
 func CreateStartupShortcut(targetPath, appName string) error {
-	startupPath := filepath.Join(os.Getenv("APPDATA"), "Microsoft\\Windows\\Start Menu\\Programs\\Startup", appName+".lnk") // Startup papkasida yorliq yo‘li
-	err := createShortcutVBScript(startupPath, targetPath)                                                                  // VBScript orqali yorliq yaratadi
-	if err != nil {                                                                                                         // Agar xatolik bo‘lsa
-		return fmt.Errorf("startup papkasiga yorliq yaratishda xatolik: %v", err) // Xatolikni qaytaradi
-	}
-	return nil // Muvaffaqiyatli yakunlanadi
+	return NewShortcutManager().Create(Shortcut{Location: ShortcutAutoStart, Name: appName, Target: targetPath}) // Joriy tizimga mos yorliq
 }
 
 // RemoveStartupShortcut - Startup papkasidan yorliqni o‘chiradi
 func RemoveStartupShortcut(appName string) error {
-	startupPath := filepath.Join(os.Getenv("APPDATA"), "Microsoft\\Windows\\Start Menu\\Programs\\Startup", appName+".lnk") // Startup papkasida yorliq yo‘li
-	if _, err := os.Stat(startupPath); err == nil {                                                                         // Agar yorliq mavjud bo‘lsa
-		err := os.Remove(startupPath) // Yorliqni o‘chiradi
-		if err != nil {               // Agar xatolik bo‘lsa
-			fmt.Println("startup papkasidan yorliq o‘chirishda xatolik:", err)          // Xatolikni konsolga chiqaradi
-			return fmt.Errorf("startup papkasidan yorliq o‘chirishda xatolik: %v", err) // Xatolikni qaytaradi
-		}
-		fmt.Println("startup papkasidan yorliq o‘chirildi:", startupPath) // Muvaffaqiyat xabarini chiqaradi
-	}
-	return nil // Muvaffaqiyatli yakunlanadi
+	return NewShortcutManager().Remove(ShortcutAutoStart, appName)
 }
 
 // RemoveDesktopShortcut - Ishchi stoldan yorliqni o‘chiradi
 func RemoveDesktopShortcut(appName string) error {
-	desktopPath := filepath.Join(os.Getenv("USERPROFILE"), "Desktop", appName+".lnk") // Ishchi stolda yorliq yo‘li
-	if _, err := os.Stat(desktopPath); err == nil {                                   // Agar yorliq mavjud bo‘lsa
-		err := os.Remove(desktopPath) // Yorliqni o‘chiradi
-		if err != nil {               // Agar xatolik bo‘lsa
-			fmt.Println("ishchi stoldan yorliq o‘chirishda xatolik:", err)          // Xatolikni konsolga chiqaradi
-			return fmt.Errorf("ishchi stoldan yorliq o‘chirishda xatolik: %v", err) // Xatolikni qaytaradi
-		}
-		fmt.Println("ishchi stoldan yorliq o‘chirildi:", desktopPath) // Muvaffaqiyat xabarini chiqaradi
-	}
-	return nil // Muvaffaqiyatli yakunlanadi
+	return NewShortcutManager().Remove(ShortcutDesktop, appName)
 }
 
 // RemoveStartMenuShortcut - Start menyudan yorliqni o‘chiradi
 func RemoveStartMenuShortcut(appName string) error {
-	startMenuPath := filepath.Join(os.Getenv("APPDATA"), "Microsoft\\Windows\\Start Menu\\Programs", appName+".lnk") // Start menyuda yorliq yo‘li
-	if _, err := os.Stat(startMenuPath); err == nil {                                                                // Agar yorliq mavjud bo‘lsa
-		err := os.Remove(startMenuPath) // Yorliqni o‘chiradi
-		if err != nil {                 // Agar xatolik bo‘lsa
-			fmt.Println("start menyudan yorliq o‘chirishda xatolik:", err)          // Xatolikni konsolga chiqaradi
-			return fmt.Errorf("start menyudan yorliq o‘chirishda xatolik: %v", err) // Xatolikni qaytaradi
-		}
-		fmt.Println("start menyudan yorliq o‘chirildi:", startMenuPath) // Muvaffaqiyat xabarini chiqaradi
-	}
-	return nil // Muvaffaqiyatli yakunlanadi
+	return NewShortcutManager().Remove(ShortcutStartMenu, appName)
 }
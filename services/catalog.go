@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"      // So‘rovlarni bekor qilish uchun
+	"fmt"          // Xatolik xabarlarini formatlash uchun
+	"main/config"  // Katalog manbalari sozlamalari
+	"main/models"  // `models` paketidagi tuzilmalarni ishlatish uchun
+	"main/version" // Kanallardagi versiyalarni taqqoslash uchun
+	"sync"         // Manbalarni parallel so‘rash uchun
+)
+
+// SourceError - bitta katalog manbasini so‘rashdagi xatolik
+type SourceError struct {
+	Source string // Manba nomi
+	Err    error  // Asl xatolik
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("%s katalogi: %v", e.Source, e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// FetchCatalogs - barcha manbalardan dasturlarni standart mijoz bilan oladi
+func FetchCatalogs(sources []config.Source, channels ...string) ([]models.Software, []error) {
+	return DefaultClient.FetchCatalogs(context.Background(), sources, channels...)
+}
+
+// FetchCatalogs - barcha manbalardan berilgan kanallardagi dasturlarni parallel oladi va bitta ro‘yxatga birlashtiradi
+// (kanallar ko‘rsatilmasa, faqat stable). Har bir dasturga uni bergan manba nomi va kanali yoziladi.
+// Bir xil ID va kanalli dasturlardan ustuvorligi (Priority) kattaroq manbadagisi qoladi, teng bo‘lsa
+// sozlamalarda oldinroq turgan manba ustun. Ishlamagan manbalar xatoliklari alohida qaytariladi,
+// qolganlarining natijasi baribir birlashtiriladi.
+func (c *Client) FetchCatalogs(ctx context.Context, sources []config.Source, channels ...string) ([]models.Software, []error) {
+	if len(channels) == 0 {
+		channels = []string{models.ChannelStable}
+	}
+
+	type request struct {
+		source  int    // sources dagi o‘rni
+		channel string // So‘ralgan kanal
+	}
+	var requests []request // Manba va kanal juftliklari (tartib saqlanadi)
+	for i := range sources {
+		for _, ch := range channels {
+			requests = append(requests, request{source: i, channel: models.NormalizeChannel(ch)})
+		}
+	}
+
+	results := make([][]models.Software, len(requests)) // Har bir so‘rov natijasi
+	errs := make([]error, len(requests))                // Har bir so‘rov xatoligi
+
+	var wg sync.WaitGroup
+	for i, req := range requests { // Har bir so‘rovni alohida goroutine da bajaradi
+		wg.Add(1)
+		go func(i int, req request) {
+			defer wg.Done()
+			src := sources[req.source]
+			list, err := c.FetchAPIData(ctx, src.CatalogURL(req.channel))
+			if err != nil {
+				errs[i] = &SourceError{Source: src.Name, Err: err}
+				return
+			}
+			for j := range list {
+				list[j].Source = src.Name // Dastur qaysi manbadan kelganini yozadi
+				if list[j].Channel == "" {
+					list[j].Channel = req.channel // Server kanalni ko‘rsatmasa, so‘ralgan kanal
+				}
+				list[j].Channel = models.NormalizeChannel(list[j].Channel)
+			}
+			results[i] = list
+		}(i, req)
+	}
+	wg.Wait()
+
+	var merged []models.Software   // Birlashtirilgan ro‘yxat
+	index := make(map[string]int)  // ID + kanal -> merged dagi o‘rni
+	owner := make(map[string]int)  // ID + kanal -> uni bergan manba o‘rni
+	var failures []error           // Ishlamagan manbalar xatoliklari
+	for i, list := range results { // So‘rovlar tartibida birlashtiradi
+		if errs[i] != nil {
+			failures = append(failures, errs[i])
+			continue
+		}
+		src := requests[i].source
+		for _, software := range list {
+			key := software.ID + "\x00" + software.Channel
+			pos, exists := index[key]
+			if !exists { // Yangi ID
+				index[key] = len(merged)
+				owner[key] = src
+				merged = append(merged, software)
+				continue
+			}
+			if sources[src].Priority > sources[owner[key]].Priority { // Ustuvorroq manba eski yozuvni almashtiradi
+				merged[pos] = software
+				owner[key] = src
+			}
+		}
+	}
+	return merged, failures
+}
+
+// ResolveChannels - har bir dastur uchun channelFor(ID) kanalida va undan barqarorroq kanallarda
+// mavjud versiyalardan eng yangisini tanlaydi (masalan beta kanalida yangiroq stable versiya ham taklif qilinadi).
+// Natijada har bir ID bir marta, ro‘yxatdagi birinchi uchragan tartibida qoladi.
+func ResolveChannels(entries []models.Software, channelFor func(id string) string) []models.Software {
+	var resolved []models.Software
+	index := make(map[string]int) // ID -> resolved dagi o‘rni
+	for _, software := range entries {
+		if models.ChannelRank(software.Channel) > models.ChannelRank(channelFor(software.ID)) {
+			continue // Tanlangan kanaldan beqarorroq
+		}
+		pos, exists := index[software.ID]
+		if !exists {
+			index[software.ID] = len(resolved)
+			resolved = append(resolved, software)
+			continue
+		}
+		current := resolved[pos]
+		c := version.Compare(software.Version, current.Version)
+		if c > 0 || (c == 0 && models.ChannelRank(software.Channel) < models.ChannelRank(current.Channel)) { // Tengida barqarorrog‘i
+			resolved[pos] = software
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"   // So‘rovlarni bekor qilish va vaqt chegaralari uchun
+	"errors"    // Maxsus xatoliklarni yaratish uchun
+	"fmt"       // Xatolik xabarlarini formatlash uchun
+	"io"        // Javob tanasini tashlab yuborish uchun
+	"math/rand" // Kutish vaqtiga tasodifiy qo‘shimcha (jitter) uchun
+	"net"       // Ulanish vaqt chegaralari uchun
+	"net/http"  // HTTP so‘rovlar uchun
+	"time"      // Vaqt oraliqlari uchun
+)
+
+// HTTP so‘rovlardagi xatoliklar
+var (
+	ErrNetwork  = errors.New("tarmoq xatoligi")      // Server bilan ulanib bo‘lmadi yoki ulanish uzildi
+	ErrTimeout  = errors.New("so'rov vaqti tugadi")  // Vaqt chegarasi tugadi
+	ErrCanceled = errors.New("so'rov bekor qilindi") // Foydalanuvchi yoki dastur so‘rovni bekor qildi
+)
+
+// StatusError - server muvaffaqiyatsiz HTTP holat kodini qaytardi
+type StatusError struct {
+	URL        string // So‘rov URL i
+	StatusCode int    // HTTP holat kodi
+	Status     string // Holat matni (masalan "503 Service Unavailable")
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("serverdan noto'g'ri javob: %s (%s)", e.Status, e.URL)
+}
+
+// Retryable - so‘rovni qayta urinish mantiqiymi (5xx va 429)
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// Standart qiymatlar
+const (
+	DefaultTimeout         = 30 * time.Second // Katalog va metama'lumot so‘rovlari uchun
+	DefaultDownloadTimeout = 30 * time.Minute // Bitta paketni to‘liq yuklash uchun
+	DefaultMaxRetries      = 3                // Qayta urinishlar soni
+	DefaultRetryDelay      = 500 * time.Millisecond
+	DefaultMaxRetryDelay   = 10 * time.Second
+)
+
+// Client - katalog serveri bilan ishlovchi HTTP mijoz
+type Client struct {
+	HTTP            *http.Client  // Asl HTTP mijoz
+	Timeout         time.Duration // Katalog so‘rovi uchun vaqt chegarasi (0 - chegarasiz)
+	DownloadTimeout time.Duration // Paket yuklash uchun vaqt chegarasi (0 - chegarasiz)
+	MaxRetries      int           // 5xx va tarmoq xatoliklarida qayta urinishlar soni
+	RetryDelay      time.Duration // Birinchi qayta urinishdan oldingi kutish (har safar ikki baravar oshadi)
+	MaxRetryDelay   time.Duration // Kutish vaqtining yuqori chegarasi
+}
+
+// DefaultClient - paket darajasidagi funksiyalar ishlatadigan mijoz
+var DefaultClient = NewClient(DefaultTimeout, DefaultDownloadTimeout)
+
+// NewClient - berilgan vaqt chegaralari bilan yangi mijoz yaratadi.
+// Ulanish va javob sarlavhalarini kutish ham `timeout` bilan cheklanadi, shuning uchun
+// osilib qolgan server yuklashni cheksiz to‘xtatib qo‘ya olmaydi.
+func NewClient(timeout, downloadTimeout time.Duration) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() // Standart transport sozlamalaridan nusxa
+	if timeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+		transport.TLSHandshakeTimeout = timeout
+		transport.ResponseHeaderTimeout = timeout
+	}
+	return &Client{
+		HTTP:            &http.Client{Transport: transport},
+		Timeout:         timeout,
+		DownloadTimeout: downloadTimeout,
+		MaxRetries:      DefaultMaxRetries,
+		RetryDelay:      DefaultRetryDelay,
+		MaxRetryDelay:   DefaultMaxRetryDelay,
+	}
+}
+
+// withTimeout - kontekstga vaqt chegarasini qo‘shadi (0 bo‘lsa, o‘zgartirmaydi)
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// get - GET so‘rov yuboradi, 5xx va tarmoq xatoliklarida eksponensial kutish bilan qayta urinadi.
+// 5xx dan boshqa holat kodlari chaqiruvchiga javob sifatida qaytariladi.
+func (c *Client) get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil) // Kontekstli so‘rov yaratadi
+		if err != nil {
+			return nil, fmt.Errorf("so'rov yaratishda xatolik: %v", err)
+		}
+		for key, values := range header { // Qo‘shimcha sarlavhalarni qo‘shadi
+			req.Header[key] = values
+		}
+
+		resp, err := c.HTTP.Do(req) // So‘rovni yuboradi
+		if err != nil {
+			if ctxErr := contextError(ctx, url); ctxErr != nil { // Bekor qilingan yoki vaqti tugagan
+				return nil, ctxErr
+			}
+			err = fmt.Errorf("%w: %s - %v", ErrNetwork, url, err)
+		} else if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Ulanishni qayta ishlatish uchun tanani o‘qib tashlaydi
+			resp.Body.Close()
+			err = &StatusError{URL: url, StatusCode: resp.StatusCode, Status: resp.Status}
+		} else {
+			return resp, nil // Muvaffaqiyatli yoki qayta urinib bo‘lmaydigan javob
+		}
+
+		if attempt >= c.MaxRetries { // Urinishlar tugadi
+			return nil, err
+		}
+		if waitErr := c.wait(ctx, url, attempt); waitErr != nil { // Keyingi urinishdan oldin kutadi
+			return nil, waitErr
+		}
+	}
+}
+
+// wait - attempt-urinishdan keyin eksponensial kutadi; kontekst bekor qilinsa darhol qaytadi
+func (c *Client) wait(ctx context.Context, url string, attempt int) error {
+	delay := c.RetryDelay << uint(attempt) // 0.5s, 1s, 2s, ...
+	if delay <= 0 || (c.MaxRetryDelay > 0 && delay > c.MaxRetryDelay) {
+		delay = c.MaxRetryDelay
+	}
+	if delay > 0 {
+		delay += time.Duration(rand.Int63n(int64(delay)/4 + 1)) // Bir vaqtda qayta urinmasliklari uchun
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return contextError(ctx, url)
+	}
+}
+
+// contextError - kontekst xatoligini ErrCanceled yoki ErrTimeout ga aylantiradi
+func contextError(ctx context.Context, url string) error {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Errorf("%w: %s", ErrCanceled, url)
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%w: %s", ErrTimeout, url)
+	}
+	return nil
+}
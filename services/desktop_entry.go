@@ -0,0 +1,334 @@
+package services
+
+import (
+	"bufio"         // user-dirs.dirs ni qatorma-qator o‘qish uchun
+	"fmt"           // .desktop faylini yig‘ish uchun
+	"image/png"     // Ikonka o‘lchamini aniqlash uchun
+	"io"            // Ikonkani nusxalash uchun
+	"os"            // Fayllar va muhit o‘zgaruvchilari uchun
+	"path/filepath" // XDG papkalari yo‘llari uchun
+	"strings"       // Qiymatlarni ekranlash uchun
+	"unicode"       // Fayl nomini tozalash uchun
+)
+
+// desktopEntryPrefix - do‘kon yaratgan .desktop fayllari va ikonkalari nomining boshlanishi
+// (tizimdagi boshqa ilovalar yozuvlari bilan to‘qnashmaslik uchun)
+const desktopEntryPrefix = "appstore-"
+
+// DesktopEntries - freedesktop .desktop yorliqlari (Linux va boshqa XDG tizimlar)
+type DesktopEntries struct{}
+
+// Create - .desktop faylini yozadi; ikonka XDG ikonka mavzusiga o‘rnatiladi
+func (DesktopEntries) Create(s Shortcut) error {
+	return shortcutError(s.Location, false, writeDesktopEntry(s))
+}
+
+// writeDesktopEntry - .desktop faylini yozadi
+func writeDesktopEntry(s Shortcut) error {
+	dir, err := desktopEntryDir(s.Location)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	id := desktopEntryID(s.Name)
+	var b strings.Builder
+	b.WriteString("[Desktop Entry]\n")
+	b.WriteString("Type=Application\n")
+	b.WriteString("Version=1.0\n")
+	fmt.Fprintf(&b, "Name=%s\n", escapeDesktopValue(s.Name))
+	if s.Comment != "" {
+		fmt.Fprintf(&b, "Comment=%s\n", escapeDesktopValue(s.Comment))
+	}
+	exec := []string{quoteExecArg(s.Target)}
+	for _, arg := range s.Args {
+		exec = append(exec, quoteExecArg(arg))
+	}
+	fmt.Fprintf(&b, "Exec=%s\n", escapeDesktopValue(strings.Join(exec, " ")))
+	if s.WorkDir != "" {
+		fmt.Fprintf(&b, "Path=%s\n", escapeDesktopValue(s.WorkDir))
+	}
+	if icon := installIcon(id, s.Icon); icon != "" {
+		fmt.Fprintf(&b, "Icon=%s\n", escapeDesktopValue(icon))
+	}
+	b.WriteString("Terminal=false\n")
+	if s.Location == ShortcutAutoStart {
+		b.WriteString("X-GNOME-Autostart-enabled=true\n")
+	}
+
+	// Ishchi stoldagi yorliqni fayl menejerlari faqat bajariluvchi bo‘lsa ishga tushiradi
+	return os.WriteFile(filepath.Join(dir, id+".desktop"), []byte(b.String()), 0755)
+}
+
+// Remove - .desktop faylni o‘chiradi. Dasturning boshqa yorlig‘i qolmasa, ikonkalari ham o‘chiriladi.
+func (m DesktopEntries) Remove(location ShortcutLocation, name string) error {
+	dir, err := desktopEntryDir(location)
+	if err != nil {
+		return shortcutError(location, true, err)
+	}
+	if err := os.Remove(filepath.Join(dir, desktopEntryID(name)+".desktop")); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return shortcutError(location, true, err)
+	}
+	if left, err := m.List(name); err == nil && len(left) == 0 { // Ikonka boshqa yorliqda ishlatilmaydi
+		removeIcons(desktopEntryID(name))
+	}
+	return nil
+}
+
+// List - dasturning mavjud .desktop yorliqlari (maqsad fayli Exec kalitidan o‘qiladi)
+func (DesktopEntries) List(name string) ([]Shortcut, error) {
+	var shortcuts []Shortcut
+	for _, location := range ShortcutLocations {
+		dir, err := desktopEntryDir(location)
+		if err != nil {
+			return nil, err
+		}
+		shortcut, err := readDesktopEntry(filepath.Join(dir, desktopEntryID(name)+".desktop"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		shortcut.Location = location
+		shortcuts = append(shortcuts, shortcut)
+	}
+	return shortcuts, nil
+}
+
+// Verify - yorliq bor va kerakli faylga ishora qiladimi
+func (m DesktopEntries) Verify(s Shortcut) error {
+	return verifyShortcut(m, s, func(a, b string) bool { return filepath.Clean(a) == filepath.Clean(b) })
+}
+
+// readDesktopEntry - .desktop faylidan yorliq maydonlarini o‘qiydi
+func readDesktopEntry(path string) (Shortcut, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Shortcut{}, err
+	}
+	var shortcut Shortcut
+	group := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			group = line
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || group != "[Desktop Entry]" {
+			continue
+		}
+		value = unescapeDesktopValue(strings.TrimSpace(value))
+		switch strings.TrimSpace(key) {
+		case "Name":
+			shortcut.Name = value
+		case "Exec":
+			if args := splitExec(value); len(args) > 0 {
+				shortcut.Target, shortcut.Args = args[0], args[1:]
+			}
+		case "Path":
+			shortcut.WorkDir = value
+		case "Comment":
+			shortcut.Comment = value
+		case "Icon":
+			shortcut.Icon = value
+		}
+	}
+	return shortcut, nil
+}
+
+// desktopEntryDir - yorliq joyiga mos XDG papkasi
+func desktopEntryDir(location ShortcutLocation) (string, error) {
+	switch location {
+	case ShortcutDesktop:
+		return xdgDesktopDir()
+	case ShortcutStartMenu:
+		data, err := xdgDataHome()
+		return filepath.Join(data, "applications"), err
+	case ShortcutAutoStart:
+		config, err := xdgConfigHome()
+		return filepath.Join(config, "autostart"), err
+	}
+	return "", fmt.Errorf("noma'lum yorliq joyi: %s", location)
+}
+
+// xdgDataHome - $XDG_DATA_HOME yoki ~/.local/share
+func xdgDataHome() (string, error) {
+	return xdgDir("XDG_DATA_HOME", ".local", "share")
+}
+
+// xdgConfigHome - $XDG_CONFIG_HOME yoki ~/.config
+func xdgConfigHome() (string, error) {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// xdgDir - muhit o‘zgaruvchisidagi mutlaq yo‘l yoki uy papkasidagi standart papka
+func xdgDir(env string, fallback ...string) (string, error) {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) { // Spetsifikatsiya bo‘yicha nisbiy yo‘l e'tiborsiz qoldiriladi
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append([]string{home}, fallback...)...), nil
+}
+
+// xdgDesktopDir - ishchi stol papkasi: user-dirs.dirs dagi XDG_DESKTOP_DIR
+// (tarjima qilingan nomlar uchun, masalan "Рабочий стол"), bo‘lmasa ~/Desktop
+func xdgDesktopDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	config, err := xdgConfigHome()
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Open(filepath.Join(config, "user-dirs.dirs"))
+	if err != nil {
+		return filepath.Join(home, "Desktop"), nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		value := strings.TrimPrefix(line, "XDG_DESKTOP_DIR=")
+		if value == line {
+			continue
+		}
+		value = strings.Trim(value, `"`)
+		value = strings.Replace(value, "$HOME", home, 1)
+		if filepath.IsAbs(value) && filepath.Clean(value) != filepath.Clean(home) { // Uy papkasi - ishchi stol o‘chirilgan
+			return value, nil
+		}
+	}
+	return filepath.Join(home, "Desktop"), nil
+}
+
+// desktopEntryID - dastur nomidan .desktop fayli va ikonka nomi (harf, raqam va "-" dan iborat)
+func desktopEntryID(name string) string {
+	id := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, strings.TrimSpace(name))
+	return desktopEntryPrefix + id
+}
+
+// escapeDesktopValue - .desktop qiymatidagi maxsus belgilarni ekranlaydi
+func escapeDesktopValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\t", `\t`, "\r", `\r`).Replace(value)
+}
+
+// unescapeDesktopValue - escapeDesktopValue ning teskarisi
+func unescapeDesktopValue(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\t`, "\t", `\r`, "\r", `\s`, " ").Replace(value)
+}
+
+// quoteExecArg - Exec kalitidagi bitta argumentni qo‘shtirnoqqa oladi
+// (bo‘sh joy va maxsus belgilar bo‘lgan yo‘llar uchun; "%" maydon kodi sifatida tushunilmasligi kerak)
+func quoteExecArg(arg string) string {
+	arg = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`, `%`, `%%`).Replace(arg)
+	return `"` + arg + `"`
+}
+
+// splitExec - Exec kalitini argumentlarga ajratadi (quoteExecArg ning teskarisi; maydon kodlari tashlab yuboriladi)
+func splitExec(exec string) []string {
+	var (
+		args    []string
+		b       strings.Builder
+		inQuote bool
+		started bool
+	)
+	for i := 0; i < len(exec); i++ {
+		c := exec[i]
+		switch {
+		case c == '"':
+			inQuote, started = !inQuote, true
+		case c == '\\' && inQuote && i+1 < len(exec):
+			i++
+			b.WriteByte(exec[i])
+		case c == '%' && i+1 < len(exec):
+			i++
+			if exec[i] == '%' {
+				b.WriteByte('%')
+				started = true
+			}
+		case c == ' ' && !inQuote:
+			if started {
+				args = append(args, b.String())
+				b.Reset()
+				started = false
+			}
+		default:
+			b.WriteByte(c)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, b.String())
+	}
+	return args
+}
+
+// installIcon - PNG ikonkani $XDG_DATA_HOME/icons/hicolor/<o‘lcham>/apps ga nusxalaydi va ikonka nomini qaytaradi.
+// O‘lchami aniqlanmasa (kvadrat bo‘lmagan yoki PNG emas), mutlaq yo‘l qaytariladi; ikonka bo‘lmasa bo‘sh.
+func installIcon(id, src string) string {
+	if src == "" {
+		return ""
+	}
+	file, err := os.Open(src)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	cfg, err := png.DecodeConfig(file)
+	if err != nil || cfg.Width != cfg.Height {
+		return src
+	}
+	data, err := xdgDataHome()
+	if err != nil {
+		return src
+	}
+
+	dir := filepath.Join(data, "icons", "hicolor", fmt.Sprintf("%dx%d", cfg.Width, cfg.Height), "apps")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return src
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return src
+	}
+	out, err := os.Create(filepath.Join(dir, id+".png"))
+	if err != nil {
+		return src
+	}
+	_, err = io.Copy(out, file)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return src
+	}
+	return id
+}
+
+// removeIcons - installIcon o‘rnatgan barcha o‘lchamdagi ikonkalarni o‘chiradi
+func removeIcons(id string) {
+	data, err := xdgDataHome()
+	if err != nil {
+		return
+	}
+	matches, _ := filepath.Glob(filepath.Join(data, "icons", "hicolor", "*", "apps", id+".png"))
+	for _, path := range matches {
+		os.Remove(path)
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"archive/zip"   // ZIP arxivlar bilan ishlash uchun
+	"context"       // Ochishni bekor qilish uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"io"            // Fayl o‘qish/yozish uchun
+	"os"            // Fayl tizimi bilan ishlash uchun
+	"path"          // ZIP ichidagi ("/" bilan ajratilgan) yo‘llar uchun
+	"path/filepath" // Diskdagi fayl yo‘llarini boshqarish uchun
+	"strings"       // Satrlar bilan ishlash uchun
+)
+
+// Paketni ochishda xavfli yozuvlar uchun maxsus xatoliklar
+var (
+	ErrUnsafePath    = errors.New("xavfli fayl yo'li")                                    // "../" yoki absolyut yo‘l papkadan tashqariga chiqadi
+	ErrSymlinkEntry  = errors.New("ramziy havolalar (symlink) taqiqlangan")               // ZIP ichidagi symlink
+	ErrSpecialFile   = errors.New("maxsus fayllar (qurilma, pipe) taqiqlangan")           // Qurilma, pipe, socket va h.k.
+	ErrNameCollision = errors.New("katta-kichik harfni hisobga olmaganda nomlar bir xil") // Masalan "App.exe" va "app.exe"
+)
+
+// UnsafeEntryError - ZIP ichidagi qaysi yozuv rad etilganini va sababini saqlaydi.
+// UI `errors.As` orqali yozuv nomini, `errors.Is` orqali sababni aniqlay oladi.
+type UnsafeEntryError struct {
+	Entry string // ZIP ichidagi yozuv nomi
+	Err   error  // Sabab (ErrUnsafePath, ErrSymlinkEntry, ErrSpecialFile yoki ErrNameCollision)
+}
+
+func (e *UnsafeEntryError) Error() string {
+	return fmt.Sprintf("paketda xavfli yozuv %q: %v", e.Entry, e.Err)
+}
+
+func (e *UnsafeEntryError) Unwrap() error {
+	return e.Err
+}
+
+// validateFileName - serverdan kelgan nom papka ajratgichlarisiz oddiy fayl nomi ekanligini tekshiradi
+func validateFileName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\:`) {
+		return &UnsafeEntryError{Entry: name, Err: ErrUnsafePath}
+	}
+	return nil
+}
+
+// safeEntryPath - ZIP yozuvi nomini tekshiradi va uning dest ichidagi to‘liq yo‘lini qaytaradi
+func safeEntryPath(dest, name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/") // Windows ajratgichlarini ham hisobga oladi
+	if slashed == "" || strings.HasPrefix(slashed, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" ||
+		(len(slashed) >= 2 && slashed[1] == ':') { // "C:..." ko‘rinishidagi disk nomlari
+		return "", &UnsafeEntryError{Entry: name, Err: ErrUnsafePath}
+	}
+
+	cleaned := path.Clean(slashed)                                              // "a/./b/../c" kabi yo‘llarni soddalashtiradi
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") { // Papkadan tashqariga chiqishga urinish
+		return "", &UnsafeEntryError{Entry: name, Err: ErrUnsafePath}
+	}
+
+	fPath := filepath.Join(dest, filepath.FromSlash(cleaned)) // Diskdagi yo‘l
+	rel, err := filepath.Rel(dest, fPath)                     // Natija haqiqatan dest ichidami, qayta tekshiradi
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &UnsafeEntryError{Entry: name, Err: ErrUnsafePath}
+	}
+	return fPath, nil
+}
+
+// validateZIPEntries - arxivdagi barcha yozuvlarni hech narsa yozilmasdan oldin tekshiradi
+// va har bir yozuv uchun chiqariladigan yo‘llarni qaytaradi. reserved - dest ichidagi band yo‘llar
+// (masalan, ochilayotgan arxivning o‘zi): ularga yoki ularning ichiga yozuv chiqarilmaydi.
+func validateZIPEntries(files []*zip.File, dest string, reserved ...string) ([]string, error) {
+	paths := make([]string, len(files)) // Har bir yozuvning diskdagi yo‘li
+	seen := make(map[string]string)     // Kichik harfli yo‘l -> asl yo‘l
+	for i, f := range files {           // Har bir yozuv bo‘yicha tsikl
+		mode := f.Mode()              // Yozuv turi va ruxsatlari
+		if mode&os.ModeSymlink != 0 { // Symlink bo‘lsa
+			return nil, &UnsafeEntryError{Entry: f.Name, Err: ErrSymlinkEntry}
+		}
+		if mode&(os.ModeDevice|os.ModeCharDevice|os.ModeNamedPipe|os.ModeSocket|os.ModeIrregular) != 0 { // Maxsus fayl bo‘lsa
+			return nil, &UnsafeEntryError{Entry: f.Name, Err: ErrSpecialFile}
+		}
+
+		fPath, err := safeEntryPath(dest, f.Name) // Yo‘lni tekshiradi
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.ToLower(fPath) // Katta-kichik harfni hisobga olmaydigan kalit
+		for _, r := range reserved {
+			if r = strings.ToLower(r); key == r || strings.HasPrefix(key, r+string(filepath.Separator)) {
+				return nil, &UnsafeEntryError{Entry: f.Name, Err: ErrNameCollision}
+			}
+		}
+		if prev, ok := seen[key]; ok && (prev != fPath || !mode.IsDir()) { // Bir xil papka yozuvlari takrorlanishi zararsiz
+			return nil, &UnsafeEntryError{Entry: f.Name, Err: ErrNameCollision}
+		}
+		seen[key] = fPath
+		paths[i] = fPath
+	}
+	return paths, nil
+}
+
+// ctxReader - kontekst bekor qilinganda o‘qishni to‘xtatadigan o‘quvchi
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil { // Bekor qilingan bo‘lsa, keyingi bo‘lakni o‘qimaydi
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// extractZIP - ZIP faylni papkaga xavfsiz ochadi va asosiy fayl yo‘lini qaytaradi.
+// ctx bekor qilinsa, ochish keyingi bo‘lakda to‘xtaydi va ErrCanceled qaytariladi.
+// Har bir fayl ochilgach, onProgress orqali StageExtract xabari yuboriladi.
+func extractZIP(ctx context.Context, zipFilePath, dirPath, mainFile string, onProgress ProgressFunc) (mainFilePath string, err error) {
+	zipReader, err := zip.OpenReader(zipFilePath) // ZIP faylni o‘qish uchun ochadi
+	if err != nil {                               // Agar xatolik bo‘lsa
+		return "", fmt.Errorf("ZIP faylni ochishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	}
+	defer zipReader.Close() // Funksiya tugagach, ZIP o‘quvchini yopadi
+
+	paths, err := validateZIPEntries(zipReader.File, dirPath, zipFilePath) // Barcha yozuvlarni oldindan tekshiradi (arxivning o‘zi ustiga yozilmaydi)
+	if err != nil {                                                        // Agar xavfli yozuv topilsa
+		return "", err // Hech narsa yozmasdan xatolikni qaytaradi
+	}
+
+	totalFiles := 0 // Papkalarsiz fayllar soni
+	for _, f := range zipReader.File {
+		if !f.FileInfo().IsDir() {
+			totalFiles++
+		}
+	}
+	extracted := 0 // Ochilgan fayllar soni
+	onProgress.report(Progress{Stage: StageExtract, TotalFiles: totalFiles})
+
+	// ZIP ichidagi fayllarni chiqarish
+	for i, f := range zipReader.File { // ZIP ichidagi har bir fayl bo‘yicha tsikl
+		if ctxErr := contextError(ctx, zipFilePath); ctxErr != nil { // Bekor qilingan bo‘lsa, to‘xtaydi
+			return "", ctxErr
+		}
+
+		fPath := paths[i]         // Faylning tekshirilgan yo‘li
+		if f.FileInfo().IsDir() { // Agar bu papka bo‘lsa
+			if err := os.MkdirAll(fPath, os.ModePerm); err != nil { // Papkani yaratadi
+				return "", fmt.Errorf("papka yaratishda xatolik: %v", err)
+			}
+			continue // Keyingi faylga o‘tadi
+		}
+
+		if err := extractZIPEntry(ctx, f, fPath); err != nil { // Faylni chiqaradi
+			if ctxErr := contextError(ctx, zipFilePath); ctxErr != nil {
+				return "", ctxErr
+			}
+			return "", err // Xatolikni qaytaradi
+		}
+
+		extracted++
+		onProgress.report(Progress{Stage: StageExtract, FilesExtracted: extracted, TotalFiles: totalFiles, File: f.Name})
+
+		if f.Name == mainFile { // Agar bu asosiy fayl bo‘lsa
+			mainFilePath = fPath // Asosiy fayl yo‘lini saqlaydi
+		}
+	}
+
+	for i, f := range zipReader.File { // Agar asosiy fayl topilmasa, birinchi oddiy faylni (papkani emas) asosiy deb oladi
+		if mainFilePath != "" {
+			break
+		}
+		if !f.FileInfo().IsDir() {
+			mainFilePath = paths[i]
+		}
+	}
+	return mainFilePath, nil
+}
+
+// extractZIPEntry - ZIP ichidagi bitta faylni diskka yozadi
+func extractZIPEntry(ctx context.Context, f *zip.File, fPath string) error {
+	rc, err := f.Open() // ZIP ichidagi faylni ochadi
+	if err != nil {     // Agar xatolik bo‘lsa
+		return fmt.Errorf("ZIP ichidagi faylni ochishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	}
+	defer rc.Close() // Fayl o‘qish tugagach, yopadi
+
+	if err := os.MkdirAll(filepath.Dir(fPath), os.ModePerm); err != nil { // Ota papka ZIP da alohida yozuv bo‘lmasligi mumkin
+		return fmt.Errorf("papka yaratishda xatolik: %v", err)
+	}
+
+	outFile, err := os.OpenFile(fPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode().Perm()|0600) // Chiqariladigan faylni yaratadi
+	if err != nil {                                                                              // Agar xatolik bo‘lsa
+		return fmt.Errorf("fayl yaratishda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	}
+	defer outFile.Close() // Funksiya tugagach, faylni yopadi
+
+	if _, err = io.Copy(outFile, &ctxReader{ctx: ctx, r: rc}); err != nil { // ZIP ichidagi faylni nusxalaydi
+		return fmt.Errorf("faylni saqlashda xatolik: %v", err) // Xatolik xabarini qaytaradi
+	}
+	return nil
+}
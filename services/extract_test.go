@@ -0,0 +1,150 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// zipEntry - sinov uchun ZIP yozuvi (faqat sarlavha, mazmunsiz)
+func zipEntry(name string, mode os.FileMode) *zip.File {
+	f := &zip.File{FileHeader: zip.FileHeader{Name: name}}
+	f.SetMode(mode)
+	return f
+}
+
+func TestValidateZIPEntries(t *testing.T) {
+	dest := t.TempDir()
+	tests := []struct {
+		name    string
+		entries []*zip.File
+		want    error // nil - arxiv qabul qilinadi
+	}{
+		{"oddiy fayllar", []*zip.File{zipEntry("app.exe", 0644), zipEntry("lib/a.dll", 0644)}, nil},
+		{"ichki nuqtalar", []*zip.File{zipEntry("lib/../app.exe", 0644)}, nil},
+		{"papka takrorlanishi", []*zip.File{zipEntry("lib/", os.ModeDir|0755), zipEntry("lib/", os.ModeDir|0755)}, nil},
+		{"zip-slip", []*zip.File{zipEntry("../evil.exe", 0644)}, ErrUnsafePath},
+		{"chuqur zip-slip", []*zip.File{zipEntry("lib/../../evil.exe", 0644)}, ErrUnsafePath},
+		{"windows ajratgichi", []*zip.File{zipEntry(`..\evil.exe`, 0644)}, ErrUnsafePath},
+		{"absolyut yo'l", []*zip.File{zipEntry("/etc/passwd", 0644)}, ErrUnsafePath},
+		{"disk nomi", []*zip.File{zipEntry("C:/Windows/evil.exe", 0644)}, ErrUnsafePath},
+		{"bo'sh nom", []*zip.File{zipEntry("", 0644)}, ErrUnsafePath},
+		{"faqat nuqta", []*zip.File{zipEntry(".", 0644)}, ErrUnsafePath},
+		{"symlink", []*zip.File{zipEntry("link", os.ModeSymlink|0777)}, ErrSymlinkEntry},
+		{"nomlangan pipe", []*zip.File{zipEntry("fifo", os.ModeNamedPipe|0644)}, ErrSpecialFile},
+		{"qurilma", []*zip.File{zipEntry("dev", os.ModeDevice|0644)}, ErrSpecialFile},
+		{"harflar to'qnashuvi", []*zip.File{zipEntry("App.exe", 0644), zipEntry("app.exe", 0644)}, ErrNameCollision},
+		{"papka va fayl to'qnashuvi", []*zip.File{zipEntry("lib/", os.ModeDir|0755), zipEntry("LIB", 0644)}, ErrNameCollision},
+		{"bir xil fayl ikki marta", []*zip.File{zipEntry("a.txt", 0644), zipEntry("./a.txt", 0644)}, ErrNameCollision},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := validateZIPEntries(tt.entries, dest)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("kutilmagan xatolik: %v", err)
+				}
+				for i, p := range paths {
+					if rel, err := filepath.Rel(dest, p); err != nil || strings.HasPrefix(rel, "..") {
+						t.Errorf("%q: yo'l papkadan tashqarida: %s", tt.entries[i].Name, p)
+					}
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("xatolik = %v, kutilgan %v", err, tt.want)
+			}
+			var entryErr *UnsafeEntryError
+			if !errors.As(err, &entryErr) {
+				t.Fatalf("xatolik UnsafeEntryError emas: %T", err)
+			}
+		})
+	}
+}
+
+func TestValidateFileName(t *testing.T) {
+	for _, name := range []string{"", ".", "..", "a/b", `a\b`, "C:x"} {
+		if err := validateFileName(name); !errors.Is(err, ErrUnsafePath) {
+			t.Errorf("validateFileName(%q) = %v, kutilgan ErrUnsafePath", name, err)
+		}
+	}
+	if err := validateFileName("My App"); err != nil {
+		t.Errorf("validateFileName(\"My App\") = %v", err)
+	}
+}
+
+func TestSafeEntryPath(t *testing.T) {
+	dest := t.TempDir()
+	got, err := safeEntryPath(dest, "lib/./x/../app.exe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dest, "lib", "app.exe"); got != want {
+		t.Errorf("safeEntryPath = %s, kutilgan %s", got, want)
+	}
+}
+
+func TestValidateZIPEntriesReserved(t *testing.T) {
+	dest := t.TempDir()
+	archive := filepath.Join(dest, "App.zip")
+	for _, name := range []string{"App.zip", "app.ZIP", "App.zip/x.txt", "./App.zip"} {
+		_, err := validateZIPEntries([]*zip.File{zipEntry(name, 0644)}, dest, archive)
+		if !errors.Is(err, ErrNameCollision) {
+			t.Errorf("%q: xatolik = %v, kutilgan ErrNameCollision", name, err)
+		}
+	}
+	if _, err := validateZIPEntries([]*zip.File{zipEntry("App.zip.txt", 0644)}, dest, archive); err != nil {
+		t.Errorf("App.zip.txt: kutilmagan xatolik: %v", err)
+	}
+}
+
+// writeZIP - berilgan yozuvlar bilan diskda ZIP fayl yaratadi (nom "/" bilan tugasa, papka)
+func writeZIP(t *testing.T, path string, names ...string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	zw := zip.NewWriter(file)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(name, "/") {
+			w.Write([]byte(name))
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractZIP(t *testing.T) {
+	dest := t.TempDir()
+	archive := filepath.Join(dest, "App.zip")
+
+	// Asosiy fayl ko‘rsatilmagan: birinchi papka emas, birinchi fayl olinadi
+	writeZIP(t, archive, "lib/", "lib/a.dll", "app.exe")
+	got, err := extractZIP(context.Background(), archive, dest, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dest, "lib", "a.dll"); got != want {
+		t.Errorf("asosiy fayl = %s, kutilgan %s", got, want)
+	}
+
+	// Arxivning o‘zi bilan bir xil nomli yozuv: arxiv ochilayotganda qirqilmaydi
+	writeZIP(t, archive, "app.exe", "App.zip")
+	if _, err := extractZIP(context.Background(), archive, dest, "app.exe", nil); !errors.Is(err, ErrNameCollision) {
+		t.Fatalf("xatolik = %v, kutilgan ErrNameCollision", err)
+	}
+	if _, err := zip.OpenReader(archive); err != nil {
+		t.Errorf("arxiv buzilgan: %v", err)
+	}
+}
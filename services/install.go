@@ -0,0 +1,762 @@
+package services
+
+import (
+	"context"       // Yuklashni bekor qilish uchun
+	"encoding/json" // Almashtirish belgisini yozish uchun
+	"errors"        // Xatolik turlarini tekshirish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"io"            // Xabarlar chiqishi uchun
+	"main/config"   // Katalog manbalari va o‘rnatish papkasi
+	"main/models"   // Dastur tuzilmalari
+	"main/storage"  // O‘rnatilgan dasturlar ro‘yxati va ishonchli kalitlar
+	"os"            // Papkalarni yaratish va o‘chirish uchun
+	"path/filepath" // Fayl yo‘llarini birlashtirish uchun
+	"strings"       // Versiya nomidan papka nomi yasash uchun
+	"time"          // O‘rnatish sanasi uchun
+)
+
+// O‘rnatishdagi xatoliklar
+var (
+	ErrInstallDir     = errors.New("o'rnatish papkasini tayyorlashda xatolik")  // Papkani yaratib yoki o‘chirib bo‘lmadi
+	ErrTrustedKeys    = errors.New("ishonchli kalitlarni o'qishda xatolik")     // Kalitlar faylini o‘qib bo‘lmadi
+	ErrNoPrevious     = errors.New("avvalgi versiya saqlanmagan")               // Orqaga qaytarish uchun versiya yo‘q
+	ErrUnknownChannel = errors.New("noma'lum reliz kanali")                     // stable, beta yoki nightly emas
+	ErrUnknownPolicy  = errors.New("noma'lum yangilash siyosati")               // manual, notify yoki auto emas
+	ErrInvalidID      = errors.New("dastur ID si papka nomi sifatida yaroqsiz") // Bo‘sh, ajratgichli yoki "." bilan boshlanadi
+)
+
+// Installer - dasturlarni o‘rnatadi, yangilaydi va o‘chiradi.
+// Har bir dastur o‘z papkasiga (Root()/ID) o‘rnatiladi, shuning uchun umumiy o‘zgaruvchan yo‘l yo‘q
+// va bir nechta o‘rnatish bir vaqtda ishlashi mumkin.
+type Installer struct {
+	Client       *Client         // HTTP mijoz (nil bo‘lsa DefaultClient)
+	Config       *config.Config  // Katalog manbalari va o‘rnatish papkasi
+	RegistryPath string          // O‘rnatilgan dasturlar ro‘yxati fayli
+	KeysPath     string          // Ishonchli nashriyotchi kalitlari fayli
+	RequireKeys  bool            // KeysPath aniq ko‘rsatilgan: fayl yo‘q yoki bo‘sh bo‘lsa, o‘rnatish to‘xtatiladi
+	Shortcuts    ShortcutManager // Yorliqlar (nil bo‘lsa, joriy operatsion tizimga mos boshqaruvchi)
+	Log          io.Writer       // To‘xtatmaydigan xatoliklar va ko‘chirish xabarlari (nil bo‘lsa, os.Stderr)
+}
+
+// NewInstaller - standart fayllar bilan o‘rnatuvchini yaratadi.
+// Ro‘yxat fayli joyini aniqlab bo‘lmasa, xatolik qaytariladi (joriy papkadagi faylga yozilmaydi).
+func NewInstaller(client *Client, cfg *config.Config) (*Installer, error) {
+	registryPath, err := storage.DefaultRegistryPath()
+	if err != nil {
+		return nil, err
+	}
+	in := &Installer{
+		Client:       client,
+		Config:       cfg,
+		RegistryPath: registryPath,
+		KeysPath:     storage.DefaultTrustedKeysPath(),
+		Shortcuts:    NewShortcutManager(),
+	}
+	if cfg != nil && cfg.KeysPath != "" { // Sozlamalarda ko‘rsatilgan kalitlar majburiy
+		in.KeysPath, in.RequireKeys = cfg.KeysPath, true
+	}
+	return in, nil
+}
+
+// Root - dasturlar o‘rnatiladigan papka (sozlamalarda ko‘rsatilmasa, operatsion tizimga mos standart papka).
+// Standart papkani aniqlab bo‘lmasa, ErrInstallDir qaytariladi (joriy papkaga o‘rnatilmaydi).
+func (in *Installer) Root() (string, error) {
+	if in.Config != nil && in.Config.InstallRoot != "" {
+		return in.Config.InstallRoot, nil
+	}
+	return DefaultInstallRoot()
+}
+
+// validateID - dastur ID si o‘rnatish papkasi ichida oddiy papka nomi ekanligini tekshiradi.
+// "." bilan boshlanadigan nomlar ham rad etiladi: ular .staging, .backup kabi xizmat papkalari bilan to‘qnashadi.
+func validateID(id string) error {
+	if err := validateFileName(id); err != nil {
+		return fmt.Errorf("%w: %q - %v", ErrInvalidID, id, err)
+	}
+	if strings.HasPrefix(id, ".") {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return nil
+}
+
+// partialPath - uzilgan yuklash davom ettiriladigan ".part" fayl yo‘li (o‘rnatish papkasi ichida)
+func partialPath(root, id string) string {
+	return filepath.Join(root, ".partial", id+".zip.part")
+}
+
+func (in *Installer) shortcuts() ShortcutManager {
+	if in.Shortcuts != nil {
+		return in.Shortcuts
+	}
+	return NewShortcutManager()
+}
+
+// logln - xabarni Log ga bitta qator qilib yozadi
+func (in *Installer) logln(args ...interface{}) {
+	out := in.Log
+	if out == nil {
+		out = os.Stderr
+	}
+	fmt.Fprintln(out, args...)
+}
+
+func (in *Installer) client() *Client {
+	if in.Client != nil {
+		return in.Client
+	}
+	return DefaultClient
+}
+
+// options - katalogdagi ma'lumotlar va ishonchli kalitlardan yuklash parametrlarini tayyorlaydi
+func (in *Installer) options(root string, software models.Software, onProgress ProgressFunc) (DownloadOptions, error) {
+	keys, err := storage.LoadTrustedKeys(in.KeysPath, in.RequireKeys) // Qadalgan nashriyotchi kalitlarini o‘qiydi
+	if err != nil {
+		return DownloadOptions{}, fmt.Errorf("%w: %v", ErrTrustedKeys, err)
+	}
+	trustStore, err := NewTrustStore(keys) // Ishonch omborini yaratadi
+	if err != nil {
+		return DownloadOptions{}, fmt.Errorf("%w: %v", ErrTrustedKeys, err)
+	}
+	return DownloadOptions{
+		SHA256:      software.SHA256,                // Kutilgan nazorat yig‘indisi
+		Size:        software.Size,                  // Kutilgan hajm
+		TrustStore:  trustStore,                     // Imzoni tekshirish uchun kalitlar
+		OnProgress:  onProgress,                     // Yuklash holati
+		PartialPath: partialPath(root, software.ID), // Uzilsa, keyingi safar davom ettiriladi
+	}, nil
+}
+
+// stagingPath - yangi versiya yuklanib, tekshiriladigan va ochiladigan vaqtinchalik papka
+func stagingPath(root, id string) string {
+	return filepath.Join(root, ".staging", id)
+}
+
+// backupPath - almashtirish yakunlanguncha eski versiya saqlanadigan papka
+func backupPath(root, id string) string {
+	return filepath.Join(root, ".backup", id)
+}
+
+// versionsPath - dasturning avvalgi versiyalari saqlanadigan papka (har bir versiya alohida ichki papkada)
+func versionsPath(root, id string) string {
+	return filepath.Join(root, ".versions", id)
+}
+
+// keepVersions - diskda saqlanadigan avvalgi versiyalar soni
+func (in *Installer) keepVersions() int {
+	if in.Config == nil {
+		return 0
+	}
+	return in.Config.KeepVersions
+}
+
+// Install - dasturni tranzaksiya sifatida o‘rnatadi yoki yangilaydi:
+// yangi versiya alohida papkada yuklanib, tekshirilib, ochiladi, so‘ng eski papka o‘rniga ko‘chiriladi.
+// Eski versiya ro‘yxatga yangi yozuv tushguncha saqlanadi; istalgan bosqichdagi xatolikda
+// (bekor qilish ham) dastur avvalgi holatiga qaytadi. Almashtirishdan oldin belgi (pendingPath) yoziladi:
+// jarayon to‘satdan to‘xtasa, keyingi o‘rnatishda recoverInstall uni yakunlaydi yoki bekor qiladi.
+func (in *Installer) Install(ctx context.Context, software models.Software, onProgress ProgressFunc) (*models.DownloadedSoftware, error) {
+	return in.install(ctx, software, onProgress, nil)
+}
+
+// SwapGuard - yangi versiya joyiga qo‘yilishidan oldin o‘rnatilgan versiya yozuvi bilan chaqiriladi;
+// xatolik qaytarsa, o‘rnatish to‘xtatiladi va eski versiya o‘zgarishsiz qoladi
+type SwapGuard func(installed models.DownloadedSoftware) error
+
+// install - Install ning o‘zi; guard nil bo‘lmasa, almashtirishdan oldin chaqiriladi
+func (in *Installer) install(ctx context.Context, software models.Software, onProgress ProgressFunc, guard SwapGuard) (*models.DownloadedSoftware, error) {
+	if err := validateID(software.ID); err != nil { // ID papka yo‘llariga qo‘shiladi
+		return nil, err
+	}
+	root, err := in.Root()
+	if err != nil {
+		return nil, err
+	}
+	dirPath := filepath.Join(root, software.ID) // Dastur papkasi
+	staging := stagingPath(root, software.ID)   // Yangi versiya papkasi
+	backup := backupPath(root, software.ID)     // Eski versiya zaxirasi
+
+	if err := in.recoverInstall(root, software.ID, dirPath); err != nil { // Avvalgi to‘xtab qolgan almashtirishni tiklaydi
+		return nil, err
+	}
+	if err := os.RemoveAll(staging); err != nil { // Oldingi chala yuklashni tozalaydi
+		return nil, fmt.Errorf("%w: %s - %v", ErrInstallDir, staging, err)
+	}
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return nil, fmt.Errorf("%w: %s - %v", ErrInstallDir, staging, err)
+	}
+
+	opts, err := in.options(root, software, onProgress)
+	if err != nil {
+		os.RemoveAll(staging)
+		return nil, err
+	}
+
+	fileURL := in.Config.DownloadURL(software.Source, software.ID, software.Channel) // Dasturni ro‘yxatga kiritgan manba va kanal
+	result, err := in.client().DownloadFile(ctx, fileURL, staging, opts)             // Yuklaydi, tekshiradi va vaqtinchalik papkaga ochadi
+	if err != nil {
+		os.RemoveAll(staging)            // Chala yozilgan papkani tozalaydi, eski versiyaga tegilmaydi
+		if errors.Is(err, ErrCanceled) { // Bekor qilingan bo‘lsa, qisman yuklangan fayl ham kerak emas
+			RemovePartial(opts.PartialPath)
+		}
+		return nil, err
+	}
+
+	old, err := storage.GetSoftwareByID(software.ID, in.RegistryPath) // Avvalgi versiya yozuvi (bo‘lmasligi mumkin)
+	if err != nil && !errors.Is(err, storage.ErrSoftwareNotFound) {
+		os.RemoveAll(staging)
+		return nil, err
+	}
+
+	record := models.DownloadedSoftware{
+		ID:           software.ID,                              // Dastur ID si
+		Name:         software.Name,                            // Dastur nomi
+		Version:      software.Version,                         // Dastur versiyasi
+		DirPath:      dirPath,                                  // O‘rnatish papkasi
+		MainFile:     filepath.Base(result.MainFilePath),       // Asosiy fayl nomi
+		Args:         software.Args,                            // Ishga tushirish argumentlari
+		IconPath:     filepath.Base(result.IconFilePath),       // Ikonka fayl nomi
+		Description:  software.Description,                     // Yorliq izohi uchun
+		Publisher:    result.Publisher,                         // Tasdiqlangan nashriyotchi
+		Source:       software.Source,                          // Katalog manbasi
+		DownloadDate: time.Now().Format("2006-01-02 15:04:05"), // Yuklash sanasi
+		IsDesktop:    software.IsDesktop,
+		IsStartup:    software.IsStartup,
+		IsAutoStart:  software.IsAutoStart,
+	}
+
+	if guard != nil && old != nil { // Yuklash davomida holat o‘zgargan bo‘lishi mumkin (masalan, dastur ishga tushirilgan)
+		if err := guard(*old); err != nil {
+			os.RemoveAll(staging)
+			return nil, err
+		}
+	}
+
+	_, statErr := os.Stat(dirPath)
+	pending := pendingSwap{Version: record.Version, DownloadDate: record.DownloadDate, Replaces: statErr == nil}
+	var dropped []models.InstalledVersion // Tasdiqlangandan keyin o‘chiriladigan eski versiyalar
+	if old != nil {                       // Almashtirilgan versiya tarixga o‘tkaziladi
+		pending.Target, dropped, err = in.retire(root, &record, *old, old.Previous, pending.Replaces)
+		if err != nil {
+			os.RemoveAll(staging)
+			return nil, err
+		}
+	}
+
+	marker := pendingPath(root, software.ID)
+	if err := writePending(marker, pending); err != nil { // Almashtirish boshlanganini diskka yozadi
+		os.RemoveAll(staging)
+		return nil, err
+	}
+	if err := swapDir(staging, dirPath, backup); err != nil { // Yangi versiyani joyiga qo‘yadi
+		os.RemoveAll(staging)
+		os.Remove(marker)
+		return nil, err
+	}
+	if err := in.commit(&record); err != nil { // Tranzaksiya shu yerda tasdiqlanadi
+		if undoErr := undoSwap(dirPath, backup, pending); undoErr != nil {
+			in.logln("Xatolik:", undoErr) // Belgi qoladi: keyingi o‘rnatishda recoverInstall tiklaydi
+		} else {
+			os.Remove(marker)
+		}
+		return nil, err
+	}
+
+	if err := finishSwap(marker, backup, pending.Target); err != nil {
+		in.logln("Xatolik:", err)
+	}
+	in.removeVersions(dropped)
+	if old != nil {
+		in.removeShortcuts(old.Name) // Eski yorliqlar yangilari bilan almashtiriladi
+		if old.DirPath != "" && old.DirPath != dirPath {
+			os.RemoveAll(old.DirPath) // Boshqa o‘rnatish papkasida qolgan eski versiya
+		}
+	}
+	in.createShortcuts(record)
+	return &record, nil
+}
+
+// Update - o‘rnatilgan dasturni yangi versiyaga almashtiradi (Install bilan bir xil tranzaksiya).
+// Yangi versiya o‘rnatilmasa, eski versiya va uning yozuvi o‘zgarishsiz qoladi.
+func (in *Installer) Update(ctx context.Context, software models.Software, onProgress ProgressFunc) (*models.DownloadedSoftware, error) {
+	return in.Install(ctx, software, onProgress)
+}
+
+// Channel - dastur kuzatadigan kanal: ro‘yxatda alohida tanlangan bo‘lsa o‘sha, aks holda umumiy sozlama
+func (in *Installer) Channel(record *models.DownloadedSoftware) string {
+	if record != nil && record.Channel != "" {
+		return models.NormalizeChannel(record.Channel)
+	}
+	if in.Config != nil {
+		return models.NormalizeChannel(in.Config.Channel)
+	}
+	return models.ChannelStable
+}
+
+// SetChannel - o‘rnatilgan dastur uchun kanalni tanlaydi (bo‘sh satr - umumiy sozlamaga qaytaradi)
+func (in *Installer) SetChannel(id, channel string) error {
+	if channel != "" && models.ChannelRank(channel) < 0 {
+		return fmt.Errorf("%w: %q", ErrUnknownChannel, channel)
+	}
+	if channel != "" {
+		channel = models.NormalizeChannel(channel)
+	}
+	return storage.UpdateDownloadedSoftware(id, in.RegistryPath, func(current *models.DownloadedSoftware) (models.DownloadedSoftware, error) {
+		if current == nil {
+			return models.DownloadedSoftware{}, fmt.Errorf("%w: ID = %s", storage.ErrSoftwareNotFound, id)
+		}
+		record := *current
+		record.Channel = channel
+		return record, nil
+	})
+}
+
+// SetPolicy - o‘rnatilgan dastur uchun yangilash siyosatini saqlaydi (manual, notify yoki auto)
+func (in *Installer) SetPolicy(id, policy string) error {
+	if !models.ValidPolicy(policy) {
+		return fmt.Errorf("%w: %q", ErrUnknownPolicy, policy)
+	}
+	return storage.UpdateDownloadedSoftware(id, in.RegistryPath, func(current *models.DownloadedSoftware) (models.DownloadedSoftware, error) {
+		if current == nil {
+			return models.DownloadedSoftware{}, fmt.Errorf("%w: ID = %s", storage.ErrSoftwareNotFound, id)
+		}
+		record := *current
+		record.UpdatePolicy = models.NormalizePolicy(policy)
+		return record, nil
+	})
+}
+
+// FetchCatalog - barcha manbalardan kerakli kanallarni so‘rab, har bir dastur uchun
+// uning kanalidagi eng yangi versiyani qaytaradi (o‘rnatilganlari uchun ro‘yxatdagi tanlov hisobga olinadi)
+func (in *Installer) FetchCatalog(ctx context.Context) ([]models.Software, []error) {
+	return in.fetchCatalog(ctx, nil)
+}
+
+// fetchCatalog - FetchCatalog bilan bir xil, lekin override dagi dasturlar uchun berilgan kanal ishlatiladi
+func (in *Installer) fetchCatalog(ctx context.Context, override map[string]string) ([]models.Software, []error) {
+	installed, err := storage.LoadDownloadedSoftware(in.RegistryPath)
+	if err != nil {
+		return nil, []error{err}
+	}
+	records := make(map[string]*models.DownloadedSoftware, len(installed))
+	for i := range installed {
+		records[installed[i].ID] = &installed[i]
+	}
+	channelFor := func(id string) string {
+		if channel := override[id]; channel != "" {
+			return models.NormalizeChannel(channel)
+		}
+		return in.Channel(records[id])
+	}
+
+	needed := models.ChannelsUpTo(in.Channel(nil)) // Umumiy kanal va undan barqarorroqlari
+	for id := range records {
+		if more := models.ChannelsUpTo(channelFor(id)); len(more) > len(needed) {
+			needed = more
+		}
+	}
+	for id := range override {
+		if more := models.ChannelsUpTo(channelFor(id)); len(more) > len(needed) {
+			needed = more
+		}
+	}
+
+	entries, errs := in.client().FetchCatalogs(ctx, in.Config.Catalogs(), needed...)
+	return ResolveChannels(entries, channelFor), errs
+}
+
+// Rollback - dasturni diskda saqlab qolingan avvalgi versiyaga qaytaradi (version bo‘sh bo‘lsa, eng yangisiga).
+// Joriy versiya tarixga o‘tkaziladi, shuning uchun keyin yana unga qaytish mumkin. Yorliqlar qayta yaratiladi.
+func (in *Installer) Rollback(id, version string) (*models.DownloadedSoftware, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+	current, err := storage.GetSoftwareByID(id, in.RegistryPath)
+	if err != nil {
+		return nil, err
+	}
+	idx := -1
+	for i, prev := range current.Previous {
+		if version == "" || prev.Version == version {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoPrevious, id, version)
+	}
+	prev := current.Previous[idx]
+	history := append(append([]models.InstalledVersion{}, current.Previous[:idx]...), current.Previous[idx+1:]...) // Qaytarilayotgan versiyasiz tarix
+
+	root, err := in.Root()
+	if err != nil {
+		return nil, err
+	}
+	dirPath := current.DirPath
+	if dirPath == "" {
+		dirPath = filepath.Join(root, id)
+	}
+	if err := in.recoverInstall(root, id, dirPath); err != nil {
+		return nil, err
+	}
+
+	record := *current
+	record.Version = prev.Version
+	record.DirPath = dirPath
+	record.MainFile = prev.MainFile
+	record.Args = prev.Args
+	record.IconPath = prev.IconPath
+	record.Publisher = prev.Publisher
+	record.Source = prev.Source
+	record.DownloadDate = prev.DownloadDate
+
+	_, statErr := os.Stat(dirPath)
+	pending := pendingSwap{Version: record.Version, DownloadDate: record.DownloadDate, From: prev.DirPath, Replaces: statErr == nil}
+	target, dropped, err := in.retire(root, &record, *current, history, pending.Replaces) // Joriy versiya tarixga o‘tadi
+	if err != nil {
+		return nil, err
+	}
+	pending.Target = target
+
+	backup := backupPath(root, id)
+	marker := pendingPath(root, id)
+	if err := writePending(marker, pending); err != nil {
+		return nil, err
+	}
+	if err := swapDir(prev.DirPath, dirPath, backup); err != nil { // Avvalgi versiyani joyiga qo‘yadi
+		os.Remove(marker)
+		return nil, err
+	}
+	if err := in.commit(&record); err != nil {
+		if undoErr := undoSwap(dirPath, backup, pending); undoErr != nil { // Avvalgi versiyani tarixga, joriyni joyiga qaytaradi
+			in.logln("Xatolik:", undoErr)
+		} else {
+			os.Remove(marker)
+		}
+		return nil, err
+	}
+
+	if err := finishSwap(marker, backup, target); err != nil {
+		in.logln("Xatolik:", err)
+	}
+	in.removeVersions(dropped)
+	in.removeShortcuts(current.Name)
+	in.createShortcuts(record)
+	return &record, nil
+}
+
+// commit - yangi yozuvni ro‘yxatga saqlaydi (tranzaksiya tasdig‘i). Kanal va yangilash siyosati
+// saqlash paytidagi yozuvdan olinadi: yuklash davomida SetChannel yoki SetPolicy bilan qilingan tanlov yo‘qolmaydi.
+func (in *Installer) commit(record *models.DownloadedSoftware) error {
+	return storage.UpdateDownloadedSoftware(record.ID, in.RegistryPath, func(current *models.DownloadedSoftware) (models.DownloadedSoftware, error) {
+		if current != nil {
+			record.Channel = current.Channel           // Foydalanuvchi tanlagan kanal yangilashda saqlanadi
+			record.UpdatePolicy = current.UpdatePolicy // Yangilash siyosati ham
+		}
+		return *record, nil
+	})
+}
+
+// retire - almashtiriladigan old versiyani record.Previous ga qo‘shadi (keepOld bo‘lsa, ya'ni eski papka diskda bo‘lsa).
+// Ro‘yxat keepVersions() tagacha qisqartiriladi; chiqib qolgan versiyalar dropped sifatida qaytariladi
+// (ular ro‘yxat yozilgandan keyin o‘chiriladi). target - eski versiya tasdiqdan keyin ko‘chiriladigan papka
+// (bo‘sh bo‘lsa, eski versiya saqlanmaydi); papka shu yerda bo‘shatiladi, ko‘chirish finishSwap da bajariladi.
+func (in *Installer) retire(root string, record *models.DownloadedSoftware, old models.DownloadedSoftware, history []models.InstalledVersion, keepOld bool) (target string, dropped []models.InstalledVersion, err error) {
+	keep := in.keepVersions()
+	versionDir := filepath.Join(versionsPath(root, old.ID), versionDirName(old.Version))
+
+	var kept []models.InstalledVersion
+	for _, v := range history {
+		switch {
+		case v.Version == old.Version || v.Version == record.Version: // Takrorlangan versiyalar
+			if v.DirPath != versionDir {
+				dropped = append(dropped, v)
+			}
+		default:
+			kept = append(kept, v)
+		}
+	}
+
+	if keep > 0 && keepOld {
+		if err := os.MkdirAll(filepath.Dir(versionDir), 0755); err != nil {
+			return "", nil, fmt.Errorf("%w: %s - %v", ErrInstallDir, versionDir, err)
+		}
+		if err := os.RemoveAll(versionDir); err != nil {
+			return "", nil, fmt.Errorf("%w: %s - %v", ErrInstallDir, versionDir, err)
+		}
+		target = versionDir
+		kept = append([]models.InstalledVersion{{
+			Version:      old.Version,
+			DirPath:      target,
+			MainFile:     old.MainFile,
+			Args:         old.Args,
+			IconPath:     old.IconPath,
+			Publisher:    old.Publisher,
+			Source:       old.Source,
+			DownloadDate: old.DownloadDate,
+		}}, kept...)
+	}
+	if len(kept) > keep {
+		dropped = append(dropped, kept[keep:]...)
+		kept = kept[:keep]
+	}
+	record.Previous = kept
+	return target, dropped, nil
+}
+
+// removeVersions - tarixdan chiqarilgan versiyalar papkalarini o‘chiradi
+func (in *Installer) removeVersions(versions []models.InstalledVersion) {
+	for _, v := range versions {
+		if v.DirPath == "" {
+			continue
+		}
+		if err := os.RemoveAll(v.DirPath); err != nil {
+			in.logln("Xatolik:", err)
+		}
+	}
+}
+
+// versionDirName - versiya satridan xavfsiz papka nomi yasaydi
+func versionDirName(version string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_' || r == '+' {
+			return r
+		}
+		return '_'
+	}, version)
+	if name == "" || name == "." || name == ".." {
+		return "unknown"
+	}
+	return name
+}
+
+// swapDir - staging papkasini dirPath o‘rniga ko‘chiradi; eski papka backup ga olinadi.
+// Ikkinchi ko‘chirish muvaffaqiyatsiz bo‘lsa, eski papka joyiga qaytariladi.
+func swapDir(staging, dirPath, backup string) error {
+	hadOld := false
+	if _, err := os.Stat(dirPath); err == nil {
+		if err := os.MkdirAll(filepath.Dir(backup), 0755); err != nil {
+			return fmt.Errorf("%w: %s - %v", ErrInstallDir, backup, err)
+		}
+		if err := os.RemoveAll(backup); err != nil {
+			return fmt.Errorf("%w: %s - %v", ErrInstallDir, backup, err)
+		}
+		if err := os.Rename(dirPath, backup); err != nil { // Masalan, dastur ishlab turgan bo‘lsa (Windows)
+			return fmt.Errorf("%w: %s - %v", ErrInstallDir, dirPath, err)
+		}
+		hadOld = true
+	}
+	if err := os.Rename(staging, dirPath); err != nil {
+		if hadOld {
+			os.Rename(backup, dirPath) // Eski versiyani joyiga qaytaradi
+		}
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, dirPath, err)
+	}
+	return nil
+}
+
+// pendingPath - almashtirish belgisi fayli: u bor ekan, dastur papkasidagi versiya hali tasdiqlanmagan bo‘lishi mumkin
+func pendingPath(root, id string) string {
+	return filepath.Join(root, ".pending", id+".json")
+}
+
+// pendingSwap - almashtirishdan oldin diskka yoziladigan belgi. Kompyuter o‘chib qolsa, recoverInstall
+// ro‘yxatdagi yozuvni shu belgi bilan solishtirib, almashtirish tasdiqlanganmi yoki yo‘qligini aniqlaydi.
+type pendingSwap struct {
+	Version      string `json:"version"`          // Joyiga qo‘yilayotgan versiya
+	DownloadDate string `json:"downloadDate"`     // Uning yozuvidagi sana (bir xil versiyani qayta o‘rnatishni ajratadi)
+	From         string `json:"from,omitempty"`   // Yangi versiya olingan papka (bo‘sh bo‘lsa, staging - bekor qilinsa o‘chiriladi)
+	Target       string `json:"target,omitempty"` // Tasdiqdan keyin eski versiya ko‘chiriladigan papka (bo‘sh bo‘lsa, o‘chiriladi)
+	Replaces     bool   `json:"replaces"`         // Almashtirishdan oldin dastur papkasi mavjud edi
+}
+
+// writePending - belgini vaqtinchalik faylga yozib, so‘ng joyiga ko‘chiradi (chala yozilgan belgi qolmaydi)
+func writePending(marker string, pending pendingSwap) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(marker), 0755); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	tmp := marker + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	if err := os.Rename(tmp, marker); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	return nil
+}
+
+// finishSwap - tasdiqlangan almashtirishni yakunlaydi: eski versiya zaxiradan target ga ko‘chiriladi
+// (target bo‘sh bo‘lsa, o‘chiriladi), so‘ng belgi o‘chiriladi
+func finishSwap(marker, backup, target string) error {
+	if _, err := os.Stat(backup); err == nil {
+		if target == "" {
+			if err := os.RemoveAll(backup); err != nil {
+				return fmt.Errorf("%w: %s - %v", ErrInstallDir, backup, err)
+			}
+		} else {
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return fmt.Errorf("%w: %s - %v", ErrInstallDir, target, err)
+			}
+			if err := os.RemoveAll(target); err != nil {
+				return fmt.Errorf("%w: %s - %v", ErrInstallDir, target, err)
+			}
+			if err := os.Rename(backup, target); err != nil {
+				return fmt.Errorf("%w: %s - %v", ErrInstallDir, target, err)
+			}
+		}
+	}
+	if err := os.Remove(marker); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	return nil
+}
+
+// undoSwap - tasdiqlanmagan almashtirishni bekor qiladi: yangi versiya olingan joyiga qaytariladi
+// (staging dan kelgan bo‘lsa, o‘chiriladi), eski versiya zaxiradan dastur papkasiga qaytadi
+func undoSwap(dirPath, backup string, pending pendingSwap) error {
+	_, backupErr := os.Stat(backup)
+	if pending.Replaces && backupErr != nil {
+		return nil // Eski papka joyidan ko‘chirilmagan: almashtirish boshlanmagan yoki swapDir o‘zi qaytargan
+	}
+	if _, err := os.Stat(dirPath); err == nil {
+		if pending.From != "" {
+			if err := os.Rename(dirPath, pending.From); err != nil {
+				return fmt.Errorf("%w: %s - %v", ErrInstallDir, dirPath, err)
+			}
+		} else if err := os.RemoveAll(dirPath); err != nil {
+			return fmt.Errorf("%w: %s - %v", ErrInstallDir, dirPath, err)
+		}
+	}
+	if backupErr == nil {
+		if err := os.Rename(backup, dirPath); err != nil {
+			return fmt.Errorf("%w: %s - %v", ErrInstallDir, backup, err)
+		}
+	}
+	return nil
+}
+
+// recoverInstall - dastur almashtirish o‘rtasida to‘xtab qolgan bo‘lsa (masalan, kompyuter o‘chib qolsa), holatni tiklaydi.
+// Belgi bo‘lsa, qaror ro‘yxatdagi yozuvga qarab qilinadi: yozuv belgidagi versiyaga mos bo‘lsa, almashtirish
+// tasdiqlangan va yakunlanadi, aks holda bekor qilinib eski versiya qaytariladi. Belgisiz qolgan zaxira
+// tasdiqlangan almashtirishdan qolgan bo‘ladi (dastur papkasi yo‘q bo‘lsagina joyiga qaytariladi).
+func (in *Installer) recoverInstall(root, id, dirPath string) error {
+	backup := backupPath(root, id)
+	marker := pendingPath(root, id)
+	data, err := os.ReadFile(marker)
+	if os.IsNotExist(err) {
+		if _, err := os.Stat(backup); err != nil {
+			return nil // Zaxira yo‘q
+		}
+		if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+			err = os.Rename(backup, dirPath)
+		} else {
+			err = os.RemoveAll(backup)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s - %v", ErrInstallDir, backup, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	var pending pendingSwap
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+
+	record, err := storage.GetSoftwareByID(id, in.RegistryPath)
+	if err != nil && !errors.Is(err, storage.ErrSoftwareNotFound) {
+		return err
+	}
+	if record != nil && record.Version == pending.Version && record.DownloadDate == pending.DownloadDate {
+		return finishSwap(marker, backup, pending.Target) // Ro‘yxat yangilangan: almashtirish tasdiqlangan
+	}
+	if err := undoSwap(dirPath, backup, pending); err != nil {
+		return err
+	}
+	if err := os.Remove(marker); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrInstallDir, marker, err)
+	}
+	return nil
+}
+
+// Remove - dastur papkasini, ro‘yxatdagi yozuvini va yorliqlarini o‘chiradi
+func (in *Installer) Remove(id string) (*models.DownloadedSoftware, error) {
+	if err := validateID(id); err != nil { // Yaroqsiz ID bilan o‘rnatish papkasidan tashqaridagi papkalar o‘chirilmaydi
+		return nil, err
+	}
+	record, err := storage.GetSoftwareByID(id, in.RegistryPath)
+	if err != nil {
+		return nil, err
+	}
+	if record.DirPath != "" {
+		if err := os.RemoveAll(record.DirPath); err != nil {
+			return nil, fmt.Errorf("%w: %s - %v", ErrInstallDir, record.DirPath, err)
+		}
+	}
+	in.removeVersions(record.Previous) // Saqlab qolingan avvalgi versiyalar
+	if root, err := in.Root(); err == nil {
+		os.RemoveAll(stagingPath(root, id)) // To‘xtab qolgan yangilash qoldiqlari
+		os.RemoveAll(backupPath(root, id))
+		os.Remove(pendingPath(root, id))
+		os.RemoveAll(versionsPath(root, id))
+	}
+	if err := storage.DeleteSoftware(id, in.RegistryPath); err != nil {
+		return nil, err
+	}
+	in.removeShortcuts(record.Name)
+	return record, nil
+}
+
+// RecordShortcuts - o‘rnatilgan dastur sozlamalariga ko‘ra bo‘lishi kerak bo‘lgan yorliqlar
+// (dastur o‘z papkasida ishga tushadi, tavsifi yorliq izohiga yoziladi)
+func RecordShortcuts(record models.DownloadedSoftware) []Shortcut {
+	shortcut := Shortcut{
+		Name:    record.Name,
+		Target:  filepath.Join(record.DirPath, record.MainFile), // Dastur yo‘li
+		Args:    record.Args,
+		WorkDir: record.DirPath,
+		Comment: record.Description,
+	}
+	if record.IconPath != "" && record.IconPath != "." { // Ikonka saqlanmagan bo‘lishi mumkin
+		shortcut.Icon = filepath.Join(record.DirPath, record.IconPath)
+	}
+	var shortcuts []Shortcut
+	for _, c := range []struct {
+		location ShortcutLocation
+		enabled  bool
+	}{
+		{ShortcutDesktop, record.IsDesktop},     // Ishchi stolidagi yorliq
+		{ShortcutStartMenu, record.IsStartup},   // Start menyudagi yorliq
+		{ShortcutAutoStart, record.IsAutoStart}, // Avtomatik ishga tushish
+	} {
+		if c.enabled {
+			shortcut.Location = c.location
+			shortcuts = append(shortcuts, shortcut)
+		}
+	}
+	return shortcuts
+}
+
+// createShortcuts - dastur sozlamalariga qarab yorliqlarni yaratadi (xatoliklar o‘rnatishni to‘xtatmaydi)
+func (in *Installer) createShortcuts(record models.DownloadedSoftware) {
+	for _, shortcut := range RecordShortcuts(record) {
+		if err := in.shortcuts().Create(shortcut); err != nil {
+			in.logln("Xatolik:", err)
+		}
+	}
+}
+
+// removeShortcuts - dasturning barcha yorliqlarini o‘chiradi
+func (in *Installer) removeShortcuts(name string) {
+	for _, location := range ShortcutLocations {
+		if err := in.shortcuts().Remove(location, name); err != nil {
+			in.logln("Xatolik:", err)
+		}
+	}
+}
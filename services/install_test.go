@@ -0,0 +1,391 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"main/config"
+	"main/models"
+	"main/storage"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testPackage - sinov serveri qaytaradigan paket: ZIP ichida asosiy fayl (mazmuni versiya)
+func testPackage(t *testing.T, software models.Software) DownloadResponse {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(software.MainFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(software.Version))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return DownloadResponse{
+		ID:          software.ID,
+		Name:        software.Name,
+		Description: software.Description,
+		MainFile:    software.MainFile,
+		Version:     software.Version,
+		Icon:        base64.StdEncoding.EncodeToString([]byte("png")),
+		File:        base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}
+}
+
+// testInstaller - vaqtinchalik papkalar, soxta yorliqlar va sinov serveri bilan o‘rnatuvchi.
+// publish serverdagi joriy versiyani almashtiradi.
+func testInstaller(t *testing.T) (in *Installer, shortcuts FileShortcuts, publish func(models.Software)) {
+	var mu sync.Mutex
+	var current DownloadResponse
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/appStore/download/"+current.ID {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(current)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	cfg := config.Default()
+	cfg.BaseURL = srv.URL
+	cfg.InstallRoot = filepath.Join(dir, "apps")
+	cfg.KeepVersions = 1
+	shortcuts = FileShortcuts{Dir: filepath.Join(dir, "shortcuts")}
+	in = &Installer{
+		Client:       NewClient(5*time.Second, 5*time.Second),
+		Config:       cfg,
+		RegistryPath: filepath.Join(dir, "downloaded_software.json"),
+		KeysPath:     filepath.Join(dir, "trusted_keys.json"), // Yo‘q: imzosiz paketlar qabul qilinadi
+		Shortcuts:    shortcuts,
+		Log:          testWriter{t},
+	}
+	return in, shortcuts, func(software models.Software) {
+		pkg := testPackage(t, software)
+		mu.Lock()
+		current = pkg
+		mu.Unlock()
+	}
+}
+
+// testWriter - o‘rnatuvchi xabarlarini test jurnaliga yo‘naltiradi
+type testWriter struct{ t *testing.T }
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimSpace(string(p)))
+	return len(p), nil
+}
+
+// checkInstalled - ro‘yxatdagi yozuv, diskdagi asosiy fayl va yorliqlar kutilgan versiyaga mosligini tekshiradi
+func checkInstalled(t *testing.T, in *Installer, shortcuts FileShortcuts, id, version string, previous ...string) {
+	t.Helper()
+	record, err := storage.GetSoftwareByID(id, in.RegistryPath)
+	if err != nil {
+		t.Fatalf("ro'yxatda yozuv yo'q: %v", err)
+	}
+	if record.Version != version {
+		t.Errorf("ro'yxatdagi versiya = %s, kutilgan %s", record.Version, version)
+	}
+	root, err := in.Root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(root, id); record.DirPath != want {
+		t.Errorf("DirPath = %s, kutilgan %s", record.DirPath, want)
+	}
+	var got []string
+	for _, prev := range record.Previous {
+		got = append(got, prev.Version)
+		if data, err := os.ReadFile(filepath.Join(prev.DirPath, prev.MainFile)); err != nil || string(data) != prev.Version {
+			t.Errorf("avvalgi versiya %s diskda yo'q yoki boshqa: %q, %v", prev.Version, data, err)
+		}
+	}
+	if !reflect.DeepEqual(got, previous) {
+		t.Errorf("avvalgi versiyalar = %v, kutilgan %v", got, previous)
+	}
+	if data, err := os.ReadFile(filepath.Join(record.DirPath, record.MainFile)); err != nil || string(data) != version {
+		t.Errorf("asosiy fayl = %q, %v; kutilgan %q", data, err, version)
+	}
+
+	want := RecordShortcuts(*record)
+	if len(want) != 2 {
+		t.Fatalf("kutilgan yorliqlar soni = %d, 2 bo'lishi kerak", len(want))
+	}
+	list, err := shortcuts.List(record.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("yorliqlar = %+v, kutilgan %+v", list, want)
+	}
+	for _, s := range want {
+		if err := shortcuts.Verify(s); err != nil {
+			t.Errorf("yorliq %s: %v", s.Location, err)
+		}
+	}
+}
+
+func TestInstallerLifecycle(t *testing.T) {
+	in, shortcuts, publish := testInstaller(t)
+	ctx := context.Background()
+	software := models.Software{
+		ID:          "notes",
+		Name:        "Notes",
+		Description: "Eslatmalar",
+		MainFile:    "notes.exe",
+		Version:     "1.0.0",
+		Args:        []string{"--minimized"},
+		IsDesktop:   true,
+		IsStartup:   true,
+	}
+
+	// O‘rnatish
+	publish(software)
+	if _, err := in.Install(ctx, software, nil); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	checkInstalled(t, in, shortcuts, "notes", "1.0.0")
+
+	// Yangilash: avvalgi versiya tarixda saqlanadi, yorliqlar yangi tavsif bilan qayta yaratiladi
+	update := software
+	update.Version, update.Description = "2.0.0", "Eslatmalar va ro'yxatlar"
+	publish(update)
+	if _, err := in.Update(ctx, update, nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	checkInstalled(t, in, shortcuts, "notes", "2.0.0", "1.0.0")
+
+	// Orqaga qaytarish: joriy versiya tarixga o‘tadi
+	if _, err := in.Rollback("notes", ""); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	checkInstalled(t, in, shortcuts, "notes", "1.0.0", "2.0.0")
+
+	// O‘chirish: papkalar, yozuv va yorliqlar qolmaydi
+	if _, err := in.Remove("notes"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := storage.GetSoftwareByID("notes", in.RegistryPath); !errors.Is(err, storage.ErrSoftwareNotFound) {
+		t.Errorf("o'chirilgan dastur ro'yxatda: %v", err)
+	}
+	if list, err := shortcuts.List("Notes"); err != nil || len(list) != 0 {
+		t.Errorf("o'chirilgandan keyin yorliqlar = %+v, %v", list, err)
+	}
+	root := in.Config.InstallRoot
+	for _, dir := range []string{filepath.Join(root, "notes"), versionsPath(root, "notes"), backupPath(root, "notes"), stagingPath(root, "notes")} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s o'chirilmagan: %v", dir, err)
+		}
+	}
+}
+
+func TestInstallFailureKeepsPreviousVersion(t *testing.T) {
+	in, shortcuts, publish := testInstaller(t)
+	ctx := context.Background()
+	software := models.Software{ID: "notes", Name: "Notes", MainFile: "notes.exe", Version: "1.0.0", IsDesktop: true, IsStartup: true}
+	publish(software)
+	if _, err := in.Install(ctx, software, nil); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	// Katalogdagi yig‘indi paketga mos emas: yangilash rad etiladi, eski versiya va yorliqlar joyida
+	update := software
+	update.Version, update.SHA256 = "2.0.0", strings.Repeat("0", 64)
+	publish(update)
+	if _, err := in.Update(ctx, update, nil); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Update = %v, kutilgan ErrChecksumMismatch", err)
+	}
+	checkInstalled(t, in, shortcuts, "notes", "1.0.0")
+}
+
+func TestValidateID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want error
+	}{
+		{"notes", nil},
+		{"my-app_2.0", nil},
+		{"", ErrInvalidID},
+		{".", ErrInvalidID},
+		{"..", ErrInvalidID},
+		{"../notes", ErrInvalidID},
+		{`..\notes`, ErrInvalidID},
+		{"a/b", ErrInvalidID},
+		{"C:notes", ErrInvalidID},
+		{".staging", ErrInvalidID},
+		{".versions", ErrInvalidID},
+		{".hidden", ErrInvalidID},
+	}
+	for _, tt := range tests {
+		if err := validateID(tt.id); !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("validateID(%q) = %v, kutilgan %v", tt.id, err, tt.want)
+		}
+	}
+}
+
+func TestInstallerRejectsInvalidID(t *testing.T) {
+	in, _, _ := testInstaller(t)
+	ctx := context.Background()
+	for _, id := range []string{"../escape", ".partial", ""} {
+		software := models.Software{ID: id, Name: "Escape", MainFile: "app.exe", Version: "1.0.0"}
+		if _, err := in.Install(ctx, software, nil); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Install(%q) = %v, kutilgan ErrInvalidID", id, err)
+		}
+		if _, err := in.Rollback(id, ""); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Rollback(%q) = %v, kutilgan ErrInvalidID", id, err)
+		}
+		if _, err := in.Remove(id); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("Remove(%q) = %v, kutilgan ErrInvalidID", id, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(in.Config.InstallRoot, "..", "escape")); !os.IsNotExist(err) {
+		t.Errorf("o'rnatish papkasidan tashqarida papka yaratildi: %v", err)
+	}
+}
+
+func TestUpdateKeepsSettingsChangedDuringDownload(t *testing.T) {
+	in, shortcuts, publish := testInstaller(t)
+	ctx := context.Background()
+	software := models.Software{ID: "notes", Name: "Notes", MainFile: "notes.exe", Version: "1.0.0", IsDesktop: true, IsStartup: true}
+	publish(software)
+	if _, err := in.Install(ctx, software, nil); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	// Yuklash davomida foydalanuvchi kanal va siyosatni o‘zgartiradi
+	update := software
+	update.Version = "2.0.0"
+	publish(update)
+	var once sync.Once
+	record, err := in.Update(ctx, update, func(Progress) {
+		once.Do(func() {
+			if err := in.SetChannel("notes", models.ChannelBeta); err != nil {
+				t.Error(err)
+			}
+			if err := in.SetPolicy("notes", models.PolicyAuto); err != nil {
+				t.Error(err)
+			}
+		})
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	checkInstalled(t, in, shortcuts, "notes", "2.0.0", "1.0.0")
+	saved, err := storage.GetSoftwareByID("notes", in.RegistryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*models.DownloadedSoftware{record, saved} {
+		if r.Channel != models.ChannelBeta || r.UpdatePolicy != models.PolicyAuto {
+			t.Errorf("kanal = %q, siyosat = %q; kutilgan beta, auto", r.Channel, r.UpdatePolicy)
+		}
+	}
+}
+
+// interruptSwap - yangilash swapDir dan keyin, lekin ro‘yxat yozilishidan oldin (committed bo‘lmasa)
+// yoki yozilgandan keyin (committed bo‘lsa) to‘xtab qolgan holatni diskda yasaydi
+func interruptSwap(t *testing.T, in *Installer, id, version string, committed bool) (target string) {
+	t.Helper()
+	root := in.Config.InstallRoot
+	current, err := storage.GetSoftwareByID(id, in.RegistryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := *current
+	record.Version, record.DownloadDate = version, "2030-01-01 00:00:00"
+	target, _, err = in.retire(root, &record, *current, current.Previous, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := pendingSwap{Version: record.Version, DownloadDate: record.DownloadDate, Target: target, Replaces: true}
+	if err := writePending(pendingPath(root, id), pending); err != nil {
+		t.Fatal(err)
+	}
+	staging := stagingPath(root, id)
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, record.MainFile), []byte(version), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := swapDir(staging, current.DirPath, backupPath(root, id)); err != nil {
+		t.Fatal(err)
+	}
+	if committed {
+		if err := storage.SaveDownloadedSoftware(record, in.RegistryPath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return target
+}
+
+func TestRecoverInstall(t *testing.T) {
+	for _, committed := range []bool{false, true} {
+		in, shortcuts, publish := testInstaller(t)
+		software := models.Software{ID: "notes", Name: "Notes", MainFile: "notes.exe", Version: "1.0.0", IsDesktop: true, IsStartup: true}
+		publish(software)
+		if _, err := in.Install(context.Background(), software, nil); err != nil {
+			t.Fatalf("Install: %v", err)
+		}
+		root := in.Config.InstallRoot
+		interruptSwap(t, in, "notes", "2.0.0", committed)
+
+		if err := in.recoverInstall(root, "notes", filepath.Join(root, "notes")); err != nil {
+			t.Fatalf("recoverInstall (tasdiqlangan = %v): %v", committed, err)
+		}
+		if committed { // Ro‘yxat yangilangan: yangi versiya qoladi, eski versiya tarixga ko‘chadi
+			checkInstalled(t, in, shortcuts, "notes", "2.0.0", "1.0.0")
+		} else { // Ro‘yxat yangilanmagan: eski versiya joyiga qaytadi
+			checkInstalled(t, in, shortcuts, "notes", "1.0.0")
+		}
+		for _, path := range []string{backupPath(root, "notes"), pendingPath(root, "notes")} {
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("tasdiqlangan = %v: %s qolib ketgan: %v", committed, path, err)
+			}
+		}
+	}
+}
+
+func TestInstallGuardKeepsPreviousVersion(t *testing.T) {
+	in, shortcuts, publish := testInstaller(t)
+	ctx := context.Background()
+	software := models.Software{ID: "notes", Name: "Notes", MainFile: "notes.exe", Version: "1.0.0", IsDesktop: true, IsStartup: true}
+	publish(software)
+	if _, err := in.Install(ctx, software, nil); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	// Yuklash tugagach dastur ishlab turibdi: yangi versiya joyiga qo‘yilmaydi
+	update := software
+	update.Version = "2.0.0"
+	publish(update)
+	job := NewQueue(in, 1).EnqueueGuarded(JobUpdate, update, func(installed models.DownloadedSoftware) error {
+		if installed.Version != "1.0.0" {
+			t.Errorf("guard ga berilgan versiya = %s, kutilgan 1.0.0", installed.Version)
+		}
+		return ErrAppRunning
+	})
+	result := WaitJobs([]*Job{job})
+	if len(result.Failed) != 1 || !errors.Is(result.Failed[0].Err, ErrAppRunning) {
+		t.Fatalf("vazifa natijasi = %+v, kutilgan ErrAppRunning", result)
+	}
+	checkInstalled(t, in, shortcuts, "notes", "1.0.0")
+	if _, err := os.Stat(stagingPath(in.Config.InstallRoot, "notes")); !os.IsNotExist(err) {
+		t.Errorf("staging papkasi qolib ketgan: %v", err)
+	}
+}
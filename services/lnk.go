@@ -0,0 +1,287 @@
+package services
+
+import (
+	"bytes"         // Skript chiqishini yig‘ish uchun
+	"fmt"           // VBScript matnini va xatoliklarni formatlash uchun
+	"os"            // Muhit o‘zgaruvchilari va vaqtinchalik fayllar uchun
+	"os/exec"       // wscript/cscript ni ishga tushirish uchun
+	"path/filepath" // Yorliq yo‘llarini yig‘ish uchun
+	"strconv"       // Skript natijasidagi %XX kodlarini o‘qish uchun
+	"strings"       // Skript natijasini tozalash uchun
+	"unicode/utf16" // %uXXXX juftliklari va skript faylini UTF-16 da yozish uchun
+)
+
+// WindowsShortcuts - Windows yorliqlari (.lnk), WScript.Shell orqali yaratiladi va o‘qiladi
+type WindowsShortcuts struct{}
+
+// Create - .lnk faylni yaratadi
+func (WindowsShortcuts) Create(s Shortcut) error {
+	return shortcutError(s.Location, false, createShortcutVBScript(windowsShortcutPath(s.Location, s.Name), s))
+}
+
+// Remove - .lnk faylni o‘chiradi
+func (WindowsShortcuts) Remove(location ShortcutLocation, name string) error {
+	err := os.Remove(windowsShortcutPath(location, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return shortcutError(location, true, err)
+}
+
+// List - dasturning mavjud .lnk yorliqlari (maydonlari yorliqning o‘zidan o‘qiladi)
+func (WindowsShortcuts) List(name string) ([]Shortcut, error) {
+	var shortcuts []Shortcut
+	for _, location := range ShortcutLocations {
+		path := windowsShortcutPath(location, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		shortcut := Shortcut{Location: location, Name: name}
+		if err := readShortcutVBScript(path, &shortcut); err != nil {
+			return nil, err
+		}
+		shortcuts = append(shortcuts, shortcut)
+	}
+	return shortcuts, nil
+}
+
+// Verify - yorliq bor va kerakli faylga ishora qiladimi (Windows yo‘llari katta-kichik harfni farqlamaydi)
+func (m WindowsShortcuts) Verify(s Shortcut) error {
+	return verifyShortcut(m, s, func(a, b string) bool { return strings.EqualFold(filepath.Clean(a), filepath.Clean(b)) })
+}
+
+// windowsShortcutPath - Windows da yorliq (.lnk) fayli yo‘li (nom windowsShortcutName orqali tozalanadi)
+func windowsShortcutPath(location ShortcutLocation, name string) string {
+	file := windowsShortcutName(name) + ".lnk"
+	switch location {
+	case ShortcutDesktop:
+		return filepath.Join(os.Getenv("USERPROFILE"), "Desktop", file)
+	case ShortcutStartMenu:
+		return filepath.Join(os.Getenv("APPDATA"), "Microsoft\\Windows\\Start Menu\\Programs", file)
+	}
+	return filepath.Join(os.Getenv("APPDATA"), "Microsoft\\Windows\\Start Menu\\Programs\\Startup", file)
+}
+
+// windowsReservedNames - Windows da fayl nomi bo‘la olmaydigan qurilma nomlari
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// windowsShortcutName - serverdan kelgan dastur nomidan xavfsiz fayl nomi yasaydi: papka ajratgichlari va
+// Windows taqiqlagan belgilar "_" ga almashtiriladi, shuning uchun yorliq o‘z papkasidan tashqariga chiqmaydi
+func windowsShortcutName(name string) string {
+	clean := strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"/\|?*`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	clean = strings.TrimRight(clean, ". ") // Windows oxiridagi nuqta va bo‘shliqlarni tashlab yuboradi ("..", "." ham)
+	if clean == "" {
+		return "_"
+	}
+	if base := strings.ToUpper(strings.SplitN(clean, ".", 2)[0]); windowsReservedNames[strings.TrimSpace(base)] {
+		return "_" + clean
+	}
+	return clean
+}
+
+// createShortcutVBScript - VBScript orqali yorliq yaratish uchun umumiy funksiya
+func createShortcutVBScript(shortcutPath string, s Shortcut) error {
+	_, err := runVBScript("wscript", shortcutVBScript(shortcutPath, s))
+	return err
+}
+
+// shortcutVBScript - yorliqni yaratadigan VBScript matni
+func shortcutVBScript(shortcutPath string, s Shortcut) string {
+	// VBScript kodini to‘g‘ri formatda tayyorlaymiz
+	var script strings.Builder
+	script.WriteString("set WshShell = WScript.CreateObject(\"WScript.Shell\")\n")
+	fmt.Fprintf(&script, "set shortcut = WshShell.CreateShortcut(%s)\n", vbsString(shortcutPath))
+	fmt.Fprintf(&script, "shortcut.TargetPath = %s\n", vbsString(s.Target))
+	fmt.Fprintf(&script, "shortcut.Arguments = %s\n", vbsString(windowsArgs(s.Args)))
+	fmt.Fprintf(&script, "shortcut.WorkingDirectory = %s\n", vbsString(s.WorkDir))
+	fmt.Fprintf(&script, "shortcut.Description = %s\n", vbsString(s.Comment))
+	if s.Icon != "" {
+		fmt.Fprintf(&script, "shortcut.IconLocation = %s\n", vbsString(s.Icon+",0"))
+	}
+	script.WriteString("shortcut.Save")
+	return script.String()
+}
+
+// readShortcutVBScript - .lnk yorliq maydonlarini o‘qiydi
+func readShortcutVBScript(shortcutPath string, s *Shortcut) error {
+	var script strings.Builder
+	script.WriteString("set WshShell = WScript.CreateObject(\"WScript.Shell\")\n")
+	fmt.Fprintf(&script, "set shortcut = WshShell.CreateShortcut(%s)\n", vbsString(shortcutPath))
+	for _, field := range []string{"TargetPath", "Arguments", "WorkingDirectory", "IconLocation", "Description"} {
+		script.WriteString("WScript.Echo Escape(shortcut." + field + ")\n") // Har bir maydon alohida qatorda (ichidagi qator oxirlari kodlanadi)
+	}
+	out, err := runVBScript("cscript", script.String(), "//NoLogo") // cscript natijani standart chiqishga yozadi
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.ReplaceAll(out, "\r\n", "\n"), "\n")
+	for len(lines) < 5 {
+		lines = append(lines, "")
+	}
+	for i := range lines[:5] {
+		lines[i] = vbsUnescape(lines[i])
+	}
+	s.Target = lines[0]
+	s.Args = splitWindowsArgs(lines[1])
+	s.WorkDir = lines[2]
+	if icon := strings.TrimSuffix(lines[3], ",0"); icon != "" && icon != lines[3] { // Ikonka o‘rnatilmagan bo‘lsa faqat ",0" qaytadi
+		s.Icon = icon
+	}
+	s.Comment = lines[4]
+	return nil
+}
+
+// vbsReplacer - VBScript literalida qo‘shtirnoqlar ikkilantiriladi, qator oxirlari esa literal ichida
+// bo‘lolmagani uchun vbCr/vbLf konstantalari bilan qo‘shiladi
+var vbsReplacer = strings.NewReplacer(`"`, `""`, "\r", `" & vbCr & "`, "\n", `" & vbLf & "`)
+
+// vbsString - VBScript satr ifodasi (skript qatori buzilmaydi)
+func vbsString(value string) string {
+	return `"` + vbsReplacer.Replace(value) + `"`
+}
+
+// vbsUnescape - VBScript Escape() natijasini qayta tiklaydi: %XX - Latin-1 belgi, %uXXXX - UTF-16 birlik
+func vbsUnescape(value string) string {
+	var units []uint16
+	for i := 0; i < len(value); i++ {
+		if value[i] == '%' {
+			if i+6 <= len(value) && value[i+1] == 'u' {
+				if n, err := strconv.ParseUint(value[i+2:i+6], 16, 16); err == nil {
+					units = append(units, uint16(n))
+					i += 5
+					continue
+				}
+			}
+			if i+3 <= len(value) {
+				if n, err := strconv.ParseUint(value[i+1:i+3], 16, 8); err == nil {
+					units = append(units, uint16(n))
+					i += 2
+					continue
+				}
+			}
+		}
+		units = append(units, uint16(value[i])) // Escape() natijasi faqat ASCII
+	}
+	return string(utf16.Decode(units))
+}
+
+// windowsArgs - argumentlarni Windows buyruq qatori qoidalari bo‘yicha bitta satrga birlashtiradi
+func windowsArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+			quoted[i] = arg
+			continue
+		}
+		var b strings.Builder
+		b.WriteByte('"')
+		slashes := 0
+		for _, c := range arg {
+			switch c {
+			case '\\':
+				slashes++
+			case '"':
+				b.WriteString(strings.Repeat(`\`, slashes+1)) // Qo‘shtirnoq oldidagi teskari chiziqlar ikkilantiriladi
+				slashes = 0
+			default:
+				slashes = 0
+			}
+			b.WriteRune(c)
+		}
+		b.WriteString(strings.Repeat(`\`, slashes)) // Yopuvchi qo‘shtirnoq oldidagilar ham
+		b.WriteByte('"')
+		quoted[i] = b.String()
+	}
+	return strings.Join(quoted, " ")
+}
+
+// splitWindowsArgs - windowsArgs ning teskarisi
+func splitWindowsArgs(line string) []string {
+	var (
+		args    []string
+		b       strings.Builder
+		inQuote bool
+		started bool
+		slashes int
+	)
+	for _, c := range line {
+		switch {
+		case c == '\\':
+			slashes++
+			started = true
+			continue
+		case c == '"':
+			b.WriteString(strings.Repeat(`\`, slashes/2))
+			if slashes%2 == 1 { // Ekranlangan qo‘shtirnoq
+				b.WriteRune(c)
+			} else {
+				inQuote = !inQuote
+			}
+			slashes, started = 0, true
+			continue
+		}
+		b.WriteString(strings.Repeat(`\`, slashes))
+		slashes = 0
+		if (c == ' ' || c == '\t') && !inQuote {
+			if started {
+				args = append(args, b.String())
+				b.Reset()
+				started = false
+			}
+			continue
+		}
+		b.WriteRune(c)
+		started = true
+	}
+	b.WriteString(strings.Repeat(`\`, slashes))
+	if started {
+		args = append(args, b.String())
+	}
+	return args
+}
+
+// runVBScript - skriptni vaqtinchalik .vbs fayliga (UTF-16LE) yozib, ishga tushiradi va standart chiqishni qaytaradi
+func runVBScript(engine, script string, args ...string) (string, error) {
+	tempScript, err := os.CreateTemp(os.Getenv("TEMP"), "shortcut-*.vbs") // Har bir chaqiruv uchun alohida fayl (parallel o‘rnatishlar uchun)
+	if err != nil {
+		return "", fmt.Errorf("faylga yozishda xatolik: %v", err)
+	}
+	defer os.Remove(tempScript.Name())         // Funksiya tugagach vaqtinchalik faylni o‘chiradi
+	_, err = tempScript.Write(utf16LE(script)) // wscript UTF-8 ni ANSI kod sahifasida o‘qiydi; BOM li UTF-16 esa to‘g‘ri o‘qiladi
+	if closeErr := tempScript.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("faylga yozishda xatolik: %v", err)
+	}
+
+	cmd := exec.Command(engine, append(args, tempScript.Name())...) // VBS skriptni ishga tushirish buyrug‘i
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out    // Standart chiqishni saqlash uchun
+	cmd.Stderr = &stderr // Standart xatolik chiqishini saqlash uchun
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("skriptni ishga tushirishda xatolik: %v, stderr: %s", err, stderr.String()) // Xatolik va stderr ni qaytaradi
+	}
+	return out.String(), nil
+}
+
+// utf16LE - matnni BOM bilan UTF-16LE ga kodlaydi (Windows skript fayllari uchun)
+func utf16LE(text string) []byte {
+	units := utf16.Encode([]rune(text))
+	buf := make([]byte, 2, 2+2*len(units))
+	buf[0], buf[1] = 0xFF, 0xFE // BOM
+	for _, u := range units {
+		buf = append(buf, byte(u), byte(u>>8))
+	}
+	return buf
+}
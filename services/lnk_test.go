@@ -0,0 +1,133 @@
+package services
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestVBSString(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{``, `""`},
+		{`C:\Program Files\app.exe`, `"C:\Program Files\app.exe"`},
+		{`say "hi"`, `"say ""hi"""`},
+		{"bir\nikki", `"bir" & vbLf & "ikki"`},
+		{"bir\r\nikki", `"bir" & vbCr & "" & vbLf & "ikki"`},
+		{"\"\n", `"""" & vbLf & ""`},
+	}
+	for _, tt := range tests {
+		got := vbsString(tt.in)
+		if got != tt.want {
+			t.Errorf("vbsString(%q) = %s, kutilgan %s", tt.in, got, tt.want)
+		}
+		if strings.ContainsAny(got, "\r\n") { // Skript qatori buzilmasligi kerak
+			t.Errorf("vbsString(%q) qator oxirini o'z ichiga oladi", tt.in)
+		}
+	}
+}
+
+func TestVBSUnescape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"C%3A%5CProgram%20Files%5Capp.exe", `C:\Program Files\app.exe`},
+		{"bir%0D%0Aikki", "bir\r\nikki"},
+		{"%u0441%u043E%u0437", "соз"},
+		{"caf%E9", "café"},
+		{"%uD83D%uDE00", "😀"},
+		{"100%", "100%"}, // Tugallanmagan kod o‘zgarishsiz qoladi
+		{"%zz1", "%zz1"}, // Noto‘g‘ri kod ham
+		{"a-b_c.d", "a-b_c.d"},
+	}
+	for _, tt := range tests {
+		if got := vbsUnescape(tt.in); got != tt.want {
+			t.Errorf("vbsUnescape(%q) = %q, kutilgan %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWindowsArgsRoundTrip(t *testing.T) {
+	for _, args := range [][]string{
+		nil,
+		{"--minimized"},
+		{"--path", `C:\Program Files\data`},
+		{"", "a b", `say "hi"`, `trailing\`, `q\"`},
+	} {
+		line := windowsArgs(args)
+		got := splitWindowsArgs(line)
+		if len(args) == 0 && len(got) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, args) {
+			t.Errorf("splitWindowsArgs(windowsArgs(%q)) = %q (satr %s)", args, got, line)
+		}
+	}
+}
+
+func TestWindowsShortcutName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Notes", "Notes"},
+		{"O‘quv markazi", "O‘quv markazi"},
+		{`..\..\Startup\evil`, `.._.._Startup_evil`},
+		{"../../evil", ".._.._evil"},
+		{`C:\evil`, "C__evil"},
+		{"a<b>c|d?e*f\"g", "a_b_c_d_e_f_g"},
+		{"bir\nikki", "bir_ikki"},
+		{"..", "_"},
+		{"  ", "_"},
+		{"app. ", "app"},
+		{"CON", "_CON"},
+		{"nul.txt", "_nul.txt"},
+		{"Console", "Console"},
+	}
+	for _, tt := range tests {
+		if got := windowsShortcutName(tt.in); got != tt.want {
+			t.Errorf("windowsShortcutName(%q) = %q, kutilgan %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWindowsShortcutPathStaysInFolder(t *testing.T) {
+	t.Setenv("USERPROFILE", t.TempDir())
+	t.Setenv("APPDATA", t.TempDir())
+	for _, location := range ShortcutLocations {
+		want := filepath.Dir(windowsShortcutPath(location, "Notes"))
+		for _, name := range []string{"../../evil", `..\..\evil`, "..", "a/b"} {
+			if got := filepath.Dir(windowsShortcutPath(location, name)); got != want {
+				t.Errorf("%s: %q yorlig'i %s papkasiga tushdi, kutilgan %s", location, name, got, want)
+			}
+		}
+	}
+}
+
+func TestShortcutScriptUTF16(t *testing.T) {
+	s := Shortcut{Name: "O‘quv markazi", Target: `C:\Dasturlar\o‘quv\app.exe`, Comment: "Ta’lim dasturi — «test» 😀"}
+	script := shortcutVBScript(`C:\Users\Ali\Desktop\O‘quv markazi.lnk`, s)
+	data := utf16LE(script)
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xFE {
+		t.Fatalf("BOM yo'q: % x", data[:2])
+	}
+	if len(data)%2 != 0 {
+		t.Fatalf("UTF-16 uzunligi toq: %d", len(data))
+	}
+	units := make([]uint16, 0, len(data)/2-1)
+	for i := 2; i < len(data); i += 2 {
+		units = append(units, uint16(data[i])|uint16(data[i+1])<<8)
+	}
+	got := string(utf16.Decode(units))
+	if got != script {
+		t.Fatalf("qayta o'qilgan skript boshqa:\n%s\nkutilgan:\n%s", got, script)
+	}
+	for _, want := range []string{vbsString(s.Target), vbsString(s.Comment), "O‘quv markazi.lnk"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("skriptda %s yo'q", want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"      // Katalog so‘rovlari uchun
+	"errors"       // Maxsus xatoliklarni yaratish uchun
+	"fmt"          // Xatolik xabarlarini formatlash uchun
+	"main/models"  // Dastur va manifest tuzilmalari
+	"main/storage" // O‘rnatilgan dasturlar ro‘yxati
+	"main/version" // Versiyalarni taqqoslash uchun
+)
+
+// ErrPinUnavailable - qadalgan versiya na katalogda, na diskda saqlangan avvalgi versiyalar orasida bor
+var ErrPinUnavailable = errors.New("qadalgan versiya mavjud emas")
+
+// ActionKind - manifestni qo‘llashdagi amal turi
+type ActionKind string
+
+const (
+	ActionInstall   ActionKind = "install"   // Dasturni o‘rnatish
+	ActionUpdate    ActionKind = "update"    // Katalogdagi versiyaga o‘tish (qadalgan versiya eskiroq bo‘lsa ham)
+	ActionRollback  ActionKind = "rollback"  // Diskda saqlangan qadalgan versiyaga qaytish
+	ActionRemove    ActionKind = "remove"    // Dasturni o‘chirish
+	ActionConfigure ActionKind = "configure" // Kanal, yangilash siyosati yoki yorliqlarni o‘zgartirish
+)
+
+// Action - kompyuterni manifestga moslashtirish uchun bitta amal
+type Action struct {
+	Kind     ActionKind
+	ID       string
+	Name     string
+	From     string             // Joriy versiya (o‘rnatilmagan bo‘lsa bo‘sh)
+	To       string             // Natijadagi versiya
+	Software models.Software    // O‘rnatish va yangilash uchun katalogdagi dastur
+	App      models.ManifestApp // Manifestdagi yozuv (sozlash uchun)
+	Changes  []string           // Sozlashda nimalar o‘zgaradi (masalan "channel=beta")
+	Err      error              // Amalni bajarib bo‘lmaydi (masalan dastur katalogda yo‘q)
+}
+
+// Plan - kompyuterni manifestga moslashtirish uchun kerakli amallarni hisoblaydi (hech narsani o‘zgartirmaydi).
+// Amallar tartibi: manifestdagi dasturlar (o‘rnatish/yangilash/qaytarish, keyin sozlash), so‘ng prune bo‘yicha o‘chirishlar.
+func (in *Installer) Plan(ctx context.Context, manifest *models.Manifest) ([]Action, []error) {
+	installed, err := storage.LoadDownloadedSoftware(in.RegistryPath)
+	if err != nil {
+		return nil, []error{err}
+	}
+	records := make(map[string]models.DownloadedSoftware, len(installed))
+	for _, record := range installed {
+		records[record.ID] = record
+	}
+
+	override := make(map[string]string) // Manifestda kanali ko‘rsatilgan dasturlar
+	needCatalog := false
+	for _, app := range manifest.Apps {
+		if app.Channel != "" {
+			override[app.ID] = app.Channel
+		}
+		needCatalog = needCatalog || !app.Absent
+	}
+	var (
+		catalog []models.Software
+		errs    []error
+	)
+	if needCatalog {
+		catalog, errs = in.fetchCatalog(ctx, override)
+	}
+	available := make(map[string]models.Software, len(catalog))
+	for _, software := range catalog {
+		available[software.ID] = software
+	}
+
+	var actions []Action
+	listed := make(map[string]bool)
+	for _, app := range manifest.Apps {
+		listed[app.ID] = true
+		record, isInstalled := records[app.ID]
+		if app.Absent {
+			if isInstalled {
+				actions = append(actions, Action{Kind: ActionRemove, ID: app.ID, Name: record.Name, From: record.Version})
+			}
+			continue
+		}
+
+		software, inCatalog := available[app.ID]
+		if !isInstalled {
+			action := Action{Kind: ActionInstall, ID: app.ID, Name: software.Name, To: software.Version, Software: software, App: app}
+			switch {
+			case !inCatalog:
+				action.Err = fmt.Errorf("%w: ID = %s", storage.ErrSoftwareNotFound, app.ID)
+			case app.Version != "" && version.Compare(app.Version, software.Version) != 0:
+				action.Err = fmt.Errorf("%w: %s %s (katalogda %s)", ErrPinUnavailable, app.ID, app.Version, software.Version)
+			}
+			action.Software.IsDesktop, action.Software.IsStartup, action.Software.IsAutoStart =
+				shortcutFlags(app.Shortcuts, software.IsDesktop, software.IsStartup, software.IsAutoStart)
+			actions = append(actions, action)
+			if changes := settingChanges(app, nil); len(changes) > 0 { // O‘rnatilgandan keyin kanal va siyosat
+				actions = append(actions, Action{Kind: ActionConfigure, ID: app.ID, Name: software.Name, To: software.Version, App: app, Changes: changes})
+			}
+			continue
+		}
+
+		if action, ok := versionAction(app, record, software, inCatalog); ok {
+			action.Software.IsDesktop, action.Software.IsStartup, action.Software.IsAutoStart =
+				shortcutFlags(app.Shortcuts, action.Software.IsDesktop, action.Software.IsStartup, action.Software.IsAutoStart)
+			actions = append(actions, action)
+		}
+		if changes := settingChanges(app, &record); len(changes) > 0 {
+			actions = append(actions, Action{Kind: ActionConfigure, ID: app.ID, Name: record.Name, From: record.Version, App: app, Changes: changes})
+		}
+	}
+
+	if manifest.Prune { // Manifestda yo‘q dasturlar o‘chiriladi
+		for _, record := range installed {
+			if !listed[record.ID] {
+				actions = append(actions, Action{Kind: ActionRemove, ID: record.ID, Name: record.Name, From: record.Version})
+			}
+		}
+	}
+	return actions, errs
+}
+
+// versionAction - o‘rnatilgan dastur versiyasini manifestga moslashtiruvchi amal (kerak bo‘lmasa ok = false)
+func versionAction(app models.ManifestApp, record models.DownloadedSoftware, software models.Software, inCatalog bool) (Action, bool) {
+	action := Action{ID: app.ID, Name: record.Name, From: record.Version, App: app}
+	if app.Version == "" { // Qadalmagan: kanaldagi eng yangi versiya
+		if !inCatalog || version.Check(record.Version, software.Version) != version.UpdateAvailable {
+			return action, false
+		}
+		action.Kind, action.To, action.Software = ActionUpdate, software.Version, software
+		return action, true
+	}
+
+	if version.Compare(record.Version, app.Version) == 0 {
+		return action, false // Qadalgan versiya allaqachon o‘rnatilgan
+	}
+	action.To = app.Version
+	if inCatalog && version.Compare(software.Version, app.Version) == 0 {
+		action.Kind, action.Software = ActionUpdate, software
+		return action, true
+	}
+	for _, prev := range record.Previous {
+		if version.Compare(prev.Version, app.Version) == 0 {
+			action.Kind, action.To = ActionRollback, prev.Version
+			return action, true
+		}
+	}
+	action.Kind = ActionUpdate
+	action.Err = fmt.Errorf("%w: %s %s", ErrPinUnavailable, app.ID, app.Version)
+	return action, true
+}
+
+// settingChanges - manifestdagi kanal, siyosat va yorliqlar ro‘yxatdagi yozuvdan farqlari
+// (record nil bo‘lsa, dastur endi o‘rnatiladi: yorliqlar o‘rnatishda yaratiladi)
+func settingChanges(app models.ManifestApp, record *models.DownloadedSoftware) []string {
+	var current models.DownloadedSoftware
+	if record != nil {
+		current = *record
+	}
+	var changes []string
+	if app.Channel != "" && models.NormalizeChannel(app.Channel) != current.Channel {
+		changes = append(changes, "channel="+models.NormalizeChannel(app.Channel))
+	}
+	if app.Policy != "" && (record == nil || models.NormalizePolicy(app.Policy) != models.NormalizePolicy(current.UpdatePolicy)) {
+		changes = append(changes, "policy="+models.NormalizePolicy(app.Policy))
+	}
+	if record != nil && app.Shortcuts != nil {
+		desktop, startMenu, autoStart := shortcutFlags(app.Shortcuts, current.IsDesktop, current.IsStartup, current.IsAutoStart)
+		for _, c := range []struct {
+			name     string
+			was, now bool
+		}{{"desktop", current.IsDesktop, desktop}, {"startMenu", current.IsStartup, startMenu}, {"autoStart", current.IsAutoStart, autoStart}} {
+			if c.was != c.now {
+				changes = append(changes, fmt.Sprintf("%s=%t", c.name, c.now))
+			}
+		}
+	}
+	return changes
+}
+
+// shortcutFlags - manifestda ko‘rsatilgan yorliq sozlamalarini joriy qiymatlar ustiga qo‘yadi
+func shortcutFlags(s *models.ManifestShortcuts, desktop, startMenu, autoStart bool) (bool, bool, bool) {
+	if s == nil {
+		return desktop, startMenu, autoStart
+	}
+	if s.Desktop != nil {
+		desktop = *s.Desktop
+	}
+	if s.StartMenu != nil {
+		startMenu = *s.StartMenu
+	}
+	if s.AutoStart != nil {
+		autoStart = *s.AutoStart
+	}
+	return desktop, startMenu, autoStart
+}
+
+// Configure - manifestdagi kanal, yangilash siyosati va yorliqlarni o‘rnatilgan dasturga qo‘llaydi.
+// Yorliqlar o‘zgargan bo‘lsa, qayta yaratiladi.
+func (in *Installer) Configure(app models.ManifestApp) error {
+	var old, record models.DownloadedSoftware
+	err := storage.UpdateDownloadedSoftware(app.ID, in.RegistryPath, func(current *models.DownloadedSoftware) (models.DownloadedSoftware, error) {
+		if current == nil {
+			return models.DownloadedSoftware{}, fmt.Errorf("%w: ID = %s", storage.ErrSoftwareNotFound, app.ID)
+		}
+		old, record = *current, *current
+		if app.Channel != "" {
+			record.Channel = models.NormalizeChannel(app.Channel)
+		}
+		if app.Policy != "" {
+			record.UpdatePolicy = models.NormalizePolicy(app.Policy)
+		}
+		record.IsDesktop, record.IsStartup, record.IsAutoStart = shortcutFlags(app.Shortcuts, record.IsDesktop, record.IsStartup, record.IsAutoStart)
+		return record, nil
+	})
+	if err != nil {
+		return err
+	}
+	if old.IsDesktop != record.IsDesktop || old.IsStartup != record.IsStartup || old.IsAutoStart != record.IsAutoStart {
+		in.removeShortcuts(old.Name)
+		in.createShortcuts(record)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"main/models"   // Dastur tuzilmalari
+	"os"            // /proc ni o‘qish uchun
+	"os/exec"       // tasklist va ps buyruqlari uchun
+	"path/filepath" // Fayl yo‘llarini solishtirish uchun
+	"runtime"       // Operatsion tizimni aniqlash uchun
+	"strings"       // Buyruq natijasini tahlil qilish uchun
+)
+
+// IsRunning - o‘rnatilgan dasturning jarayoni ishlab turganmi.
+// Linux da /proc orqali papkadagi istalgan bajariluvchi fayl tekshiriladi, Windows da tasklist
+// asosiy fayl nomi bo‘yicha, boshqa tizimlarda ps. Aniqlab bo‘lmasa, xatolik qaytariladi.
+func IsRunning(record models.DownloadedSoftware) (bool, error) {
+	if record.DirPath == "" || record.MainFile == "" {
+		return false, nil
+	}
+	mainFile := filepath.Join(record.DirPath, record.MainFile)
+	switch {
+	case runtime.GOOS == "windows":
+		return runningTasklist(record.MainFile)
+	case dirExists("/proc/self"):
+		return runningProc(record.DirPath)
+	}
+	return runningPS(record.DirPath, mainFile)
+}
+
+// runningTasklist - Windows da shu nomdagi jarayon bormi
+func runningTasklist(image string) (bool, error) {
+	out, err := exec.Command("tasklist", "/FI", "IMAGENAME eq "+image, "/FO", "CSV", "/NH").Output()
+	if err != nil {
+		return false, fmt.Errorf("tasklist: %v", err)
+	}
+	return strings.Contains(strings.ToLower(string(out)), `"`+strings.ToLower(image)+`"`), nil
+}
+
+// runningProc - bajariluvchi fayli dirPath ichida bo‘lgan jarayon bormi (/proc/<pid>/exe)
+func runningProc(dirPath string) (bool, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !isDigits(entry.Name()) {
+			continue
+		}
+		exe, err := os.Readlink(filepath.Join("/proc", entry.Name(), "exe"))
+		if err != nil { // Boshqa foydalanuvchining jarayoni yoki allaqachon tugagan
+			continue
+		}
+		exe = strings.TrimSuffix(exe, " (deleted)") // Yangilashda o‘chirilgan fayl ham hisoblanadi
+		if inDir(exe, dirPath) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// runningPS - ps ro‘yxatida dastur papkasidagi fayl bormi
+func runningPS(dirPath, mainFile string) (bool, error) {
+	out, err := exec.Command("ps", "-axo", "comm=").Output()
+	if err != nil {
+		return false, fmt.Errorf("ps: %v", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == mainFile || inDir(line, dirPath) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// inDir - path dirPath ichidami
+func inDir(path, dirPath string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dirPath), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"io"   // O‘quvchini o‘rash uchun
+	"time" // Xabar berish oralig‘i uchun
+)
+
+// ProgressStage - o‘rnatish bosqichi
+type ProgressStage int
+
+const (
+	StageDownload ProgressStage = iota // Paket yuklanmoqda
+	StageVerify                        // Yig‘indi va imzo tekshirilmoqda
+	StageExtract                       // Fayllar ochilmoqda
+)
+
+// Progress - yuklash va ochish holati haqidagi xabar
+type Progress struct {
+	Stage          ProgressStage // Joriy bosqich
+	BytesReceived  int64         // Shu paytgacha olingan baytlar
+	TotalBytes     int64         // Jami baytlar (Content-Length; noma'lum bo‘lsa -1)
+	FilesExtracted int           // Ochilgan fayllar soni
+	TotalFiles     int           // Arxivdagi jami fayllar soni
+	File           string        // Hozir ochilayotgan fayl (arxiv ichidagi nomi)
+}
+
+// ProgressFunc - holat o‘zgarganda chaqiriladigan funksiya (yuklash goroutine sidan chaqiriladi)
+type ProgressFunc func(Progress)
+
+// progressInterval - yuklash paytida xabarlar orasidagi eng kam vaqt (UI ni ortiqcha yangilamaslik uchun)
+const progressInterval = 100 * time.Millisecond
+
+// report - funksiya berilgan bo‘lsa, holatni xabar qiladi
+func (f ProgressFunc) report(p Progress) {
+	if f != nil {
+		f(p)
+	}
+}
+
+// progressReader - o‘qilgan baytlarni sanaydi va vaqti-vaqti bilan xabar beradi
+type progressReader struct {
+	r          io.Reader
+	onProgress ProgressFunc
+	received   int64     // Olingan baytlar
+	total      int64     // Jami baytlar (-1 noma'lum)
+	last       time.Time // Oxirgi xabar vaqti
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.received += int64(n)
+	if now := time.Now(); err != nil || now.Sub(r.last) >= progressInterval { // Oxirgi bo‘lakda yoki oraliq o‘tganda
+		r.last = now
+		r.onProgress.report(Progress{Stage: StageDownload, BytesReceived: r.received, TotalBytes: r.total})
+	}
+	return n, err
+}
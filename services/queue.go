@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"     // Vazifalarni bekor qilish uchun
+	"errors"      // Bekor qilishni aniqlash uchun
+	"fmt"         // Xatolik xabarlarini formatlash uchun
+	"main/models" // Dastur tuzilmalari
+	"sync"        // Navbatni bir nechta goroutine dan himoyalash uchun
+	"time"        // Vazifa vaqtlari uchun
+)
+
+// JobKind - navbatdagi vazifa turi
+type JobKind int
+
+const (
+	JobInstall  JobKind = iota // Yangi o‘rnatish
+	JobUpdate                  // Mavjud dasturni yangilash
+	JobRollback                // Avvalgi versiyaga qaytarish (Software.Version - qaytariladigan versiya)
+)
+
+func (k JobKind) String() string {
+	switch k {
+	case JobUpdate:
+		return "Yangilash"
+	case JobRollback:
+		return "Orqaga qaytarish"
+	}
+	return "O'rnatish"
+}
+
+// JobState - vazifa holati
+type JobState int
+
+const (
+	JobQueued   JobState = iota // Bo‘sh ishchini kutmoqda
+	JobRunning                  // Bajarilmoqda
+	JobDone                     // Muvaffaqiyatli tugadi
+	JobFailed                   // Xatolik bilan tugadi
+	JobCanceled                 // Foydalanuvchi bekor qildi
+)
+
+func (s JobState) String() string {
+	switch s {
+	case JobQueued:
+		return "Navbatda"
+	case JobRunning:
+		return "Bajarilmoqda"
+	case JobDone:
+		return "Tayyor"
+	case JobFailed:
+		return "Xatolik"
+	case JobCanceled:
+		return "Bekor qilindi"
+	}
+	return "Noma'lum"
+}
+
+// Finished - vazifa tugaganmi (muvaffaqiyatli, xatolik bilan yoki bekor qilinib)
+func (s JobState) Finished() bool {
+	return s == JobDone || s == JobFailed || s == JobCanceled
+}
+
+// JobInfo - vazifa holatining nusxasi (kuzatuvchilarga shu ko‘rinishda yuboriladi)
+type JobInfo struct {
+	ID        int                        // Navbatdagi tartib raqami
+	Kind      JobKind                    // O‘rnatish yoki yangilash
+	Software  models.Software            // Katalogdagi dastur
+	State     JobState                   // Joriy holat
+	Progress  Progress                   // Oxirgi yuklash/ochish holati
+	Err       error                      // Xatolik (JobFailed va JobCanceled da)
+	Installed *models.DownloadedSoftware // O‘rnatilgan dastur (JobDone da)
+	Created   time.Time                  // Navbatga qo‘shilgan vaqt
+	Started   time.Time                  // Bajarila boshlagan vaqt
+	Finished  time.Time                  // Tugagan vaqt
+}
+
+// Job - navbatdagi bitta o‘rnatish, yangilash yoki orqaga qaytarish vazifasi
+type Job struct {
+	id       int
+	queue    *Queue
+	ctx      context.Context
+	cancel   context.CancelFunc
+	info     JobInfo               // queue.mu bilan himoyalangan
+	guard    SwapGuard             // Almashtirishdan oldingi tekshiruv (nil bo‘lishi mumkin)
+	watchers map[int]func(JobInfo) // Faqat shu vazifani kuzatuvchilar (masalan dastur kartasi)
+}
+
+// ID - vazifa raqami
+func (j *Job) ID() int { return j.id }
+
+// Info - vazifa holatining joriy nusxasi
+func (j *Job) Info() JobInfo {
+	j.queue.mu.Lock()
+	defer j.queue.mu.Unlock()
+	return j.info
+}
+
+// Cancel - vazifani bekor qiladi (navbatda bo‘lsa, darhol olib tashlanadi)
+func (j *Job) Cancel() { j.queue.Cancel(j.id) }
+
+// Watch - vazifa holati o‘zgarganda fn ni chaqiradi; joriy holat darhol yuboriladi.
+// Vazifa tugagach kuzatuvchilar avtomatik o‘chiriladi. Qaytarilgan funksiya kuzatishni to‘xtatadi.
+func (j *Job) Watch(fn func(JobInfo)) (stop func()) {
+	q := j.queue
+	q.mu.Lock()
+	info := j.info
+	if info.State.Finished() {
+		q.mu.Unlock()
+		fn(info)
+		return func() {}
+	}
+	q.nextWatch++
+	key := q.nextWatch
+	j.watchers[key] = fn
+	q.mu.Unlock()
+
+	fn(info)
+	return func() {
+		q.mu.Lock()
+		delete(j.watchers, key)
+		q.mu.Unlock()
+	}
+}
+
+// Queue - o‘rnatish va yangilash vazifalari navbati.
+// Belgilangan sondagi ishchilar vazifalarni navbat tartibida bajaradi.
+type Queue struct {
+	installer *Installer
+
+	mu          sync.Mutex
+	cond        *sync.Cond            // Yangi vazifa qo‘shilganini ishchilarga bildiradi
+	pending     []*Job                // Bo‘sh ishchini kutayotgan vazifalar
+	jobs        []*Job                // Barcha vazifalar (tozalanmaguncha)
+	nextID      int                   // Keyingi vazifa raqami
+	nextWatch   int                   // Keyingi kuzatuvchi kaliti
+	subscribers map[int]func(JobInfo) // Barcha vazifalarni kuzatuvchilar (masalan "Yuklamalar" oynasi)
+	wg          sync.WaitGroup        // Tugallanmagan vazifalar
+}
+
+// NewQueue - navbatni yaratadi va workers ta ishchini ishga tushiradi
+func NewQueue(installer *Installer, workers int) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	q := &Queue{
+		installer:   installer,
+		subscribers: make(map[int]func(JobInfo)),
+	}
+	q.cond = sync.NewCond(&q.mu)
+	for i := 0; i < workers; i++ {
+		go q.worker()
+	}
+	return q
+}
+
+// Installer - navbat foydalanadigan o‘rnatuvchi
+func (q *Queue) Installer() *Installer { return q.installer }
+
+// Enqueue - dasturni navbatga qo‘shadi. Shu dastur uchun tugallanmagan vazifa bo‘lsa,
+// yangisi yaratilmaydi va mavjudi qaytariladi (bitta papkaga ikki marta yozilmasligi uchun).
+func (q *Queue) Enqueue(kind JobKind, software models.Software) *Job {
+	return q.EnqueueGuarded(kind, software, nil)
+}
+
+// EnqueueGuarded - Enqueue kabi, lekin o‘rnatish yoki yangilash vazifasi yangi versiyani joyiga qo‘yishdan
+// oldin guard ni chaqiradi (masalan, yuklash davomida dastur ishga tushirilganini tekshirish uchun)
+func (q *Queue) EnqueueGuarded(kind JobKind, software models.Software, guard SwapGuard) *Job {
+	q.mu.Lock()
+	if job := q.activeLocked(software.ID); job != nil {
+		q.mu.Unlock()
+		return job
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q.nextID++
+	job := &Job{
+		id:       q.nextID,
+		queue:    q,
+		ctx:      ctx,
+		cancel:   cancel,
+		guard:    guard,
+		watchers: make(map[int]func(JobInfo)),
+		info: JobInfo{
+			ID:       q.nextID,
+			Kind:     kind,
+			Software: software,
+			State:    JobQueued,
+			Created:  time.Now(),
+		},
+	}
+	q.jobs = append(q.jobs, job)
+	q.pending = append(q.pending, job)
+	q.wg.Add(1)
+	q.mu.Unlock()
+
+	q.update(job, func(*JobInfo) {}) // "Yuklamalar" ro‘yxatiga yangi vazifani bildiradi
+	q.cond.Signal()
+	return job
+}
+
+// Active - dastur uchun tugallanmagan vazifa (bo‘lmasa nil)
+func (q *Queue) Active(softwareID string) *Job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.activeLocked(softwareID)
+}
+
+func (q *Queue) activeLocked(softwareID string) *Job {
+	for _, job := range q.jobs {
+		if job.info.Software.ID == softwareID && !job.info.State.Finished() {
+			return job
+		}
+	}
+	return nil
+}
+
+// Jobs - barcha vazifalar holati (qo‘shilgan tartibda)
+func (q *Queue) Jobs() []JobInfo {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	infos := make([]JobInfo, len(q.jobs))
+	for i, job := range q.jobs {
+		infos[i] = job.info
+	}
+	return infos
+}
+
+// ClearFinished - tugagan vazifalarni ro‘yxatdan olib tashlaydi
+func (q *Queue) ClearFinished() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var active []*Job
+	for _, job := range q.jobs {
+		if !job.info.State.Finished() {
+			active = append(active, job)
+		}
+	}
+	q.jobs = active
+}
+
+// Cancel - vazifani raqami bo‘yicha bekor qiladi
+func (q *Queue) Cancel(id int) {
+	q.mu.Lock()
+	var job *Job
+	for _, j := range q.jobs {
+		if j.id == id {
+			job = j
+			break
+		}
+	}
+	if job == nil || job.info.State.Finished() {
+		q.mu.Unlock()
+		return
+	}
+	for i, j := range q.pending {
+		if j == job { // Hali boshlanmagan: navbatdan olib, darhol tugatadi
+			q.pending = append(q.pending[:i], q.pending[i+1:]...)
+			q.mu.Unlock()
+			job.cancel()
+			q.finish(job, nil, fmt.Errorf("%w: %s", ErrCanceled, job.info.Software.ID))
+			return
+		}
+	}
+	q.mu.Unlock()
+	job.cancel() // Bajarilayotgan yuklash va ochishni to‘xtatadi
+}
+
+// Subscribe - istalgan vazifa holati o‘zgarganda fn ni chaqiradi. Qaytarilgan funksiya kuzatishni to‘xtatadi.
+func (q *Queue) Subscribe(fn func(JobInfo)) (unsubscribe func()) {
+	q.mu.Lock()
+	q.nextWatch++
+	key := q.nextWatch
+	q.subscribers[key] = fn
+	q.mu.Unlock()
+	return func() {
+		q.mu.Lock()
+		delete(q.subscribers, key)
+		q.mu.Unlock()
+	}
+}
+
+// Wait - navbatdagi barcha vazifalar tugashini kutadi
+func (q *Queue) Wait() { q.wg.Wait() }
+
+// worker - navbatdan vazifalarni olib, birma-bir bajaradi
+func (q *Queue) worker() {
+	for {
+		q.mu.Lock()
+		for len(q.pending) == 0 {
+			q.cond.Wait()
+		}
+		job := q.pending[0]
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+		q.run(job)
+	}
+}
+
+// run - bitta vazifani bajaradi
+func (q *Queue) run(job *Job) {
+	defer job.cancel()        // Kontekst resurslarini bo‘shatadi
+	if job.ctx.Err() != nil { // Navbatdan olinayotganda bekor qilingan bo‘lsa, hech narsaga tegmaydi
+		q.finish(job, nil, fmt.Errorf("%w: %s", ErrCanceled, job.info.Software.ID))
+		return
+	}
+	q.update(job, func(info *JobInfo) {
+		info.State = JobRunning
+		info.Started = time.Now()
+	})
+
+	onProgress := func(p Progress) {
+		q.update(job, func(info *JobInfo) { info.Progress = p })
+	}
+	var (
+		record *models.DownloadedSoftware
+		err    error
+	)
+	switch job.info.Kind {
+	case JobRollback:
+		record, err = q.installer.Rollback(job.info.Software.ID, job.info.Software.Version)
+	default: // O‘rnatish va yangilash bitta tranzaksiya
+		record, err = q.installer.install(job.ctx, job.info.Software, onProgress, job.guard)
+	}
+	q.finish(job, record, err)
+}
+
+// finish - vazifani yakuniy holatga o‘tkazadi
+func (q *Queue) finish(job *Job, record *models.DownloadedSoftware, err error) {
+	defer q.wg.Done()
+	q.update(job, func(info *JobInfo) {
+		info.Installed = record
+		info.Err = err
+		info.Finished = time.Now()
+		switch {
+		case err == nil:
+			info.State = JobDone
+		case errors.Is(err, ErrCanceled):
+			info.State = JobCanceled
+		default:
+			info.State = JobFailed
+		}
+	})
+}
+
+// update - vazifa holatini o‘zgartiradi va kuzatuvchilarga yangi nusxani yuboradi
+func (q *Queue) update(job *Job, change func(info *JobInfo)) {
+	q.mu.Lock()
+	change(&job.info)
+	info := job.info
+	fns := make([]func(JobInfo), 0, len(job.watchers)+len(q.subscribers))
+	for _, fn := range job.watchers {
+		fns = append(fns, fn)
+	}
+	for _, fn := range q.subscribers {
+		fns = append(fns, fn)
+	}
+	if info.State.Finished() {
+		job.watchers = nil // Tugagan vazifani endi kuzatish shart emas
+	}
+	q.mu.Unlock()
+
+	for _, fn := range fns { // Kuzatuvchilar qulfdan tashqarida chaqiriladi
+		fn(info)
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"encoding/json" // Qisman yuklash holatini saqlash uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"io"            // Faylga yozish uchun
+	"net/http"      // Range sarlavhalari uchun
+	"os"            // Fayl tizimi bilan ishlash uchun
+	"path/filepath" // Fayl yo‘llarini boshqarish uchun
+	"strconv"       // Content-Range qiymatlarini o‘qish uchun
+	"strings"       // Satrlar bilan ishlash uchun
+)
+
+// ErrBadContentRange - server qisman javobda so‘ralgandan boshqa oraliqni qaytardi
+var ErrBadContentRange = errors.New("serverdan noto'g'ri Content-Range")
+
+// partialState - ".part" fayl yonida saqlanadigan holat (davom ettirish xavfsizligi uchun)
+type partialState struct {
+	URL          string `json:"url"`          // Qaysi URL dan yuklanayotgani
+	ETag         string `json:"etag"`         // Server bergan ETag
+	LastModified string `json:"lastModified"` // Server bergan Last-Modified
+}
+
+// partialStatePath - ".part" fayl holati saqlanadigan yo‘l
+func partialStatePath(partPath string) string {
+	return partPath + ".json"
+}
+
+// RemovePartial - qisman yuklangan faylni va uning holatini o‘chiradi
+func RemovePartial(partPath string) error {
+	if partPath == "" {
+		return nil
+	}
+	os.Remove(partialStatePath(partPath))
+	if err := os.Remove(partPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// resumeHeader - ".part" fayl bo‘lsa, davom ettirish uchun Range/If-Range sarlavhalarini tayyorlaydi.
+// Server bergan validator (ETag yoki Last-Modified) bo‘lmasa, fayl o‘zgarmaganiga ishonib bo‘lmaydi,
+// shuning uchun yuklash boshidan boshlanadi.
+func resumeHeader(partPath, url string) (int64, http.Header) {
+	info, err := os.Stat(partPath)
+	if err != nil || info.Size() == 0 {
+		return 0, nil
+	}
+
+	var state partialState
+	data, err := os.ReadFile(partialStatePath(partPath))
+	if err != nil || json.Unmarshal(data, &state) != nil || state.URL != url {
+		RemovePartial(partPath) // Holati noma'lum fayl ishlatilmaydi
+		return 0, nil
+	}
+
+	validator := state.ETag
+	if validator == "" {
+		validator = state.LastModified
+	}
+	if validator == "" {
+		RemovePartial(partPath)
+		return 0, nil
+	}
+
+	header := http.Header{}
+	header.Set("Range", fmt.Sprintf("bytes=%d-", info.Size())) // Qolgan qismini so‘raydi
+	header.Set("If-Range", validator)                          // Fayl o‘zgargan bo‘lsa, server to‘liq faylni beradi
+	return info.Size(), header
+}
+
+// contentRange - "bytes start-end/total" sarlavhasidan boshlanish va jami hajmni oladi (noma'lum jami -1)
+func contentRange(resp *http.Response) (start, total int64, err error) {
+	value := strings.TrimPrefix(resp.Header.Get("Content-Range"), "bytes ")
+	rangePart, totalPart, ok := strings.Cut(value, "/")
+	startPart, _, ok2 := strings.Cut(rangePart, "-")
+	if !ok || !ok2 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrBadContentRange, resp.Header.Get("Content-Range"))
+	}
+	start, err = strconv.ParseInt(startPart, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", ErrBadContentRange, resp.Header.Get("Content-Range"))
+	}
+	total = -1
+	if totalPart != "*" {
+		if total, err = strconv.ParseInt(totalPart, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("%w: %q", ErrBadContentRange, resp.Header.Get("Content-Range"))
+		}
+	}
+	return start, total, nil
+}
+
+// savePartial - javob tanasini ".part" fayliga (offset dan boshlab) yozadi va tugagach
+// uni "<dirPath>/<name>.zip" ga ko‘chiradi. Uzilish bo‘lsa, ".part" keyingi urinish uchun qoladi.
+func savePartial(src io.Reader, resp *http.Response, url, partPath string, offset int64, dirPath, name string) (string, error) {
+	if err := validateFileName(name); err != nil { // Server bergan nom fayl nomi sifatida xavfsizligini tekshiradi
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(partPath), os.ModePerm); err != nil {
+		return "", fmt.Errorf("papka yaratishda xatolik: %v", err)
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if offset == 0 { // To‘liq javob: eski qismni tashlab, boshidan yozadi
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		state := partialState{URL: url, ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+		data, _ := json.Marshal(state)
+		if err := os.WriteFile(partialStatePath(partPath), data, 0644); err != nil {
+			return "", fmt.Errorf("yuklash holatini saqlashda xatolik: %v", err)
+		}
+	}
+
+	part, err := os.OpenFile(partPath, flags, 0644) // ".part" faylni ochadi
+	if err != nil {
+		return "", fmt.Errorf("ZIP fayl yaratishda xatolik: %v", err)
+	}
+	_, err = io.Copy(part, src) // Qolgan baytlarni yozadi
+	closeErr := part.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil { // Uzilish: ".part" saqlanib qoladi
+		return "", fmt.Errorf("ZIP faylga yozishda xatolik: %v", err)
+	}
+
+	zipFilePath := filepath.Join(dirPath, name+".zip")
+	if err := os.Rename(partPath, zipFilePath); err != nil { // To‘liq faylni o‘z joyiga ko‘chiradi
+		return "", fmt.Errorf("ZIP faylni saqlashda xatolik: %v", err)
+	}
+	os.Remove(partialStatePath(partPath))
+	return zipFilePath, nil
+}
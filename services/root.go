@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"           // Ko‘chirish xabarlari uchun
+	"main/models"   // Dastur tuzilmalari
+	"main/storage"  // O‘rnatilgan dasturlar ro‘yxati
+	"os"            // Papkalarni ko‘chirish uchun
+	"path/filepath" // Yo‘llarni solishtirish uchun
+	"runtime"       // Operatsion tizimni aniqlash uchun
+)
+
+// DefaultInstallRoot - operatsion tizimga mos standart o‘rnatish papkasi: Windows da foydalanuvchi
+// Local papkasi (%LOCALAPPDATA%), macOS da ~/Library/Application Support/appstore, boshqa tizimlarda
+// $XDG_DATA_HOME/appstore (o‘zgaruvchi bo‘lmasa ~/.local/share/appstore).
+// Papka aniqlanmasa, xatolik qaytariladi: eski ~/AppData/Local yoki boshqa foydalanuvchi papkasiga qaytilmaydi.
+func DefaultInstallRoot() (string, error) {
+	var dir string
+	var err error
+	switch runtime.GOOS {
+	case "windows":
+		if dir, err = GetUserLocalPath(); err == nil {
+			return dir, nil
+		}
+	case "darwin":
+		if dir, err = os.UserHomeDir(); err == nil {
+			return filepath.Join(dir, "Library", "Application Support", "appstore"), nil
+		}
+	default:
+		if dir, err = xdgDataHome(); err == nil {
+			return filepath.Join(dir, "appstore"), nil
+		}
+	}
+	return "", fmt.Errorf("%w: standart papka aniqlanmadi - %v", ErrInstallDir, err)
+}
+
+// Migrate - eski versiyalardan qolgan ma'lumotlarni yangi joylarga ko‘chiradi: joriy papkadagi ro‘yxat va
+// ishonchli kalitlarni foydalanuvchi papkalariga import qiladi, so‘ng eski papkadagi dasturlarni Root() ga ko‘chiradi.
+// Ishga tushishda, navbatdan oldin chaqiriladi.
+func (in *Installer) Migrate() []error {
+	var errs []error
+	if n, err := storage.ImportLegacyRegistry(in.RegistryPath); err != nil {
+		errs = append(errs, err)
+	} else if n > 0 {
+		in.logln("Eski ro'yxat import qilindi:", storage.LegacyRegistryPath, "->", in.RegistryPath)
+	}
+	if !in.RequireKeys { // Aniq ko‘rsatilgan kalitlar fayli o‘rniga eski fayl qo‘yilmaydi
+		if n, err := storage.ImportLegacyTrustedKeys(in.KeysPath); err != nil {
+			errs = append(errs, err)
+		} else if n > 0 {
+			in.logln("Eski ishonchli kalitlar import qilindi:", storage.LegacyTrustedKeysPath, "->", in.KeysPath)
+		}
+	}
+	return append(errs, in.MigrateInstallRoot()...)
+}
+
+// MigrateInstallRoot - eski standart papkaga (~/AppData/Local) o‘rnatilgan dasturlarni Root() ga ko‘chiradi:
+// dastur papkasi va saqlangan avvalgi versiyalar ko‘chiriladi, ro‘yxat yangilanadi, yorliqlar qayta yaratiladi.
+// Windows da eski papka standart bo‘lib qolgani uchun hech narsa qilinmaydi. Navbat ishga tushishidan oldin chaqiriladi.
+func (in *Installer) MigrateInstallRoot() []error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	root, err := in.Root()
+	if err != nil {
+		return []error{err}
+	}
+	legacy, err := GetUserLocalPath()
+	if err != nil || filepath.Clean(legacy) == filepath.Clean(root) { // Uy papkasi yo‘q bo‘lsa, eski papka ham yo‘q
+		return nil
+	}
+	installed, err := storage.LoadDownloadedSoftware(in.RegistryPath)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, record := range installed {
+		if record.DirPath == "" || !inDir(record.DirPath, legacy) || inDir(record.DirPath, root) {
+			continue
+		}
+		moved := record
+		moved.Previous = append([]models.InstalledVersion(nil), record.Previous...)
+		dirPath, err := moveUnder(record.DirPath, legacy, root)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		moved.DirPath = dirPath
+		for i, prev := range moved.Previous { // Avvalgi versiyalar ko‘chmasa, eski joyida ishlatilaveradi
+			if prev.DirPath == "" || !inDir(prev.DirPath, legacy) {
+				continue
+			}
+			if path, err := moveUnder(prev.DirPath, legacy, root); err != nil {
+				errs = append(errs, err)
+			} else {
+				moved.Previous[i].DirPath = path
+			}
+		}
+
+		if err := storage.SaveDownloadedSoftware(moved, in.RegistryPath); err != nil {
+			os.Rename(dirPath, record.DirPath) // Ro‘yxat eski yo‘lni ko‘rsatib turibdi
+			for i, prev := range moved.Previous {
+				if prev.DirPath != record.Previous[i].DirPath {
+					os.Rename(prev.DirPath, record.Previous[i].DirPath)
+				}
+			}
+			errs = append(errs, err)
+			continue
+		}
+		in.logln("Dastur yangi papkaga ko'chirildi:", record.DirPath, "->", dirPath)
+		in.removeShortcuts(record.Name)
+		in.createShortcuts(moved)
+	}
+
+	// Bo‘shab qolgan eski papkalar o‘chiriladi (boshqa fayllar bo‘lsa, qoladi)
+	dirs, _ := filepath.Glob(filepath.Join(legacy, ".versions", "*"))
+	for _, name := range []string{".versions", ".partial", ".staging", ".backup"} { // O‘rnatuvchining xizmat papkalari
+		dirs = append(dirs, filepath.Join(legacy, name))
+	}
+	for _, dir := range append(dirs, legacy, filepath.Dir(legacy)) {
+		os.Remove(dir)
+	}
+	return errs
+}
+
+// moveUnder - from ichidagi path ni to ichidagi xuddi shu nisbiy joyga ko‘chiradi
+func moveUnder(path, from, to string) (string, error) {
+	rel, err := filepath.Rel(from, path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s - %v", ErrInstallDir, path, err)
+	}
+	dest := filepath.Join(to, rel)
+	if _, err := os.Stat(dest); err == nil { // Yangi papkada shu nomli narsa bor: ustiga yozilmaydi
+		return "", fmt.Errorf("%w: %s - allaqachon mavjud", ErrInstallDir, dest)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", fmt.Errorf("%w: %s - %v", ErrInstallDir, dest, err)
+	}
+	if err := os.Rename(path, dest); err != nil {
+		return "", fmt.Errorf("%w: %s - %v", ErrInstallDir, path, err)
+	}
+	return dest, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDefaultInstallRoot(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("XDG papkalari faqat Linux va boshqa Unix tizimlarida")
+	}
+	home := t.TempDir()
+	tests := []struct {
+		name, home, dataHome string
+		want                 string
+		wantErr              error
+	}{
+		{"XDG_DATA_HOME", home, "/data", "/data/appstore", nil},
+		{"uy papkasi", home, "", filepath.Join(home, ".local", "share", "appstore"), nil},
+		{"nisbiy XDG_DATA_HOME", home, "data", filepath.Join(home, ".local", "share", "appstore"), nil},
+		{"uy papkasi yo'q", "", "", "", ErrInstallDir},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", tt.home)
+			t.Setenv("XDG_DATA_HOME", tt.dataHome)
+			got, err := DefaultInstallRoot()
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("xatolik = %v, kutilgan %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DefaultInstallRoot() = %q, kutilgan %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetUserLocalPath(t *testing.T) {
+	home, local := t.TempDir(), t.TempDir()
+	tests := []struct {
+		name, home, localAppData string
+		want                     string
+		wantErr                  bool
+	}{
+		{"LOCALAPPDATA", home, local, local, false},
+		{"uy papkasi", home, "", filepath.Join(home, "AppData", "Local"), false},
+		{"nisbiy LOCALAPPDATA", home, "Local", filepath.Join(home, "AppData", "Local"), false},
+		{"uy papkasi yo'q", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", tt.home)
+			t.Setenv("USERPROFILE", tt.home) // Windows da os.UserHomeDir shundan oladi
+			t.Setenv("LOCALAPPDATA", tt.localAppData)
+			got, err := GetUserLocalPath()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("xatolik = %v, kutilgan xatolik: %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetUserLocalPath() = %q, kutilgan %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"  // Maxsus xatoliklarni yaratish uchun
+	"fmt"     // Xatolik xabarlarini formatlash uchun
+	"runtime" // Operatsion tizimni aniqlash uchun
+)
+
+// Yorliqlarni tekshirishdagi xatoliklar
+var (
+	ErrShortcutMissing  = errors.New("yorliq topilmadi")
+	ErrShortcutMismatch = errors.New("yorliq boshqa faylga ishora qiladi")
+)
+
+// ShortcutLocation - yorliq joylashadigan joy
+type ShortcutLocation string
+
+const (
+	ShortcutDesktop   ShortcutLocation = "desktop"   // Ishchi stol
+	ShortcutStartMenu ShortcutLocation = "startMenu" // Start menyu (Linux da ilovalar menyusi)
+	ShortcutAutoStart ShortcutLocation = "autoStart" // Tizimga kirganda avtomatik ishga tushish
+)
+
+// ShortcutLocations - barcha yorliq joylari
+var ShortcutLocations = []ShortcutLocation{ShortcutDesktop, ShortcutStartMenu, ShortcutAutoStart}
+
+// Shortcut - bitta yorliq
+type Shortcut struct {
+	Location ShortcutLocation `json:"location"`
+	Name     string           `json:"name"`              // Dastur nomi (yorliq fayli nomi shundan olinadi)
+	Target   string           `json:"target"`            // Ishga tushiriladigan fayl
+	Icon     string           `json:"icon,omitempty"`    // Ikonka fayli (bo‘sh bo‘lishi mumkin)
+	Args     []string         `json:"args,omitempty"`    // Ishga tushirish argumentlari
+	WorkDir  string           `json:"workDir,omitempty"` // Ishchi papka (bo‘sh bo‘lsa, tizim tanlaydi)
+	Comment  string           `json:"comment,omitempty"` // Izoh (menyuda va sichqoncha ustida ko‘rinadi)
+}
+
+// ShortcutManager - yorliqlarni yaratish va boshqarish (har bir operatsion tizim uchun alohida)
+type ShortcutManager interface {
+	Create(s Shortcut) error                             // Yorliqni yaratadi (bor bo‘lsa, qayta yozadi)
+	Remove(location ShortcutLocation, name string) error // Yorliqni o‘chiradi (yo‘q bo‘lsa, xatolik emas)
+	List(name string) ([]Shortcut, error)                // Dasturning mavjud yorliqlari
+	Verify(s Shortcut) error                             // Yorliq bor va s.Target ga ishora qiladimi
+}
+
+// NewShortcutManager - joriy operatsion tizimga mos yorliqlar boshqaruvchisi:
+// Windows da .lnk (VBScript orqali), boshqa tizimlarda freedesktop .desktop fayllari
+func NewShortcutManager() ShortcutManager {
+	if runtime.GOOS == "windows" {
+		return WindowsShortcuts{}
+	}
+	return DesktopEntries{}
+}
+
+// Xatolik xabarlari uchun joy nomlari: yaratishda va o‘chirishda
+var shortcutPlaces = map[ShortcutLocation][2]string{
+	ShortcutDesktop:   {"ishchi stolga", "ishchi stoldan"},
+	ShortcutStartMenu: {"start menyuga", "start menyudan"},
+	ShortcutAutoStart: {"startup papkasiga", "startup papkasidan"},
+}
+
+// shortcutError - yaratish yoki o‘chirish xatoligiga yorliq joyini qo‘shadi
+func shortcutError(location ShortcutLocation, remove bool, err error) error {
+	if err == nil {
+		return nil
+	}
+	if remove {
+		return fmt.Errorf("%s yorliq o‘chirishda xatolik: %v", shortcutPlaces[location][1], err)
+	}
+	return fmt.Errorf("%s yorliq yaratishda xatolik: %v", shortcutPlaces[location][0], err)
+}
+
+// verifyShortcut - List natijasidan kutilgan yorliqni qidiradi (Verify uchun umumiy mantiq)
+func verifyShortcut(m ShortcutManager, s Shortcut, sameTarget func(a, b string) bool) error {
+	shortcuts, err := m.List(s.Name)
+	if err != nil {
+		return err
+	}
+	for _, found := range shortcuts {
+		if found.Location != s.Location {
+			continue
+		}
+		if !sameTarget(found.Target, s.Target) {
+			return fmt.Errorf("%w: %s (%s)", ErrShortcutMismatch, s.Name, found.Target)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s (%s)", ErrShortcutMissing, s.Name, s.Location)
+}
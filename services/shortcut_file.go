@@ -0,0 +1,66 @@
+package services
+
+import (
+	"encoding/json" // Yorliqlarni faylga yozish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"os"            // Fayllar bilan ishlash uchun
+	"path/filepath" // Yorliq fayllari yo‘llari uchun
+)
+
+// FileShortcuts - yorliqlarni faqat Dir papkasida JSON fayllar sifatida saqlaydigan soxta boshqaruvchi
+// (tashqi buyruqlar va muhit o‘zgaruvchilarisiz; testlar va CI uchun). Har bir yorliq
+// Dir/<joy>/<dastur nomi>.json faylida turadi.
+type FileShortcuts struct {
+	Dir string
+}
+
+// Create - yorliqni JSON faylga yozadi
+func (m FileShortcuts) Create(s Shortcut) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return shortcutError(s.Location, false, err)
+	}
+	path := m.path(s.Location, s.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return shortcutError(s.Location, false, err)
+	}
+	return shortcutError(s.Location, false, os.WriteFile(path, data, 0644))
+}
+
+// Remove - yorliq faylini o‘chiradi
+func (m FileShortcuts) Remove(location ShortcutLocation, name string) error {
+	err := os.Remove(m.path(location, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return shortcutError(location, true, err)
+}
+
+// List - dasturning saqlangan yorliqlari
+func (m FileShortcuts) List(name string) ([]Shortcut, error) {
+	var shortcuts []Shortcut
+	for _, location := range ShortcutLocations {
+		data, err := os.ReadFile(m.path(location, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var s Shortcut
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("yorliq faylini o'qishda xatolik: %s - %v", m.path(location, name), err)
+		}
+		shortcuts = append(shortcuts, s)
+	}
+	return shortcuts, nil
+}
+
+// Verify - yorliq bor va kerakli faylga ishora qiladimi
+func (m FileShortcuts) Verify(s Shortcut) error {
+	return verifyShortcut(m, s, func(a, b string) bool { return filepath.Clean(a) == filepath.Clean(b) })
+}
+
+func (m FileShortcuts) path(location ShortcutLocation, name string) string {
+	return filepath.Join(m.Dir, string(location), name+".json")
+}
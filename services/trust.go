@@ -0,0 +1,88 @@
+package services
+
+import (
+	"crypto/ed25519"  // Ed25519 imzolarini tekshirish uchun
+	"encoding/base64" // Kalit va imzolarni dekodlash uchun
+	"errors"          // Maxsus xatoliklarni yaratish uchun
+	"fmt"             // Xatolik xabarlarini formatlash uchun
+	"main/models"     // `models` paketidagi tuzilmalarni ishlatish uchun
+)
+
+// Nashriyotchi imzosini tekshirishdagi xatoliklar
+var (
+	ErrSignatureMissing = errors.New("paket imzolanmagan")                 // Ishonchli kalitlar bor, lekin imzo yo‘q
+	ErrUnknownPublisher = errors.New("nashriyotchi kaliti ishonchli emas") // Kalit ID si ishonch omborida yo‘q
+	ErrSignatureInvalid = errors.New("paket imzosi noto'g'ri")             // Imzo kalitga mos kelmadi
+	ErrInvalidKey       = errors.New("ishonchli kalit noto'g'ri")          // Ishonch omboridagi kalit buzilgan
+)
+
+// trustedKey - ishonch omboridagi bitta tekshirilgan kalit
+type trustedKey struct {
+	publisher string            // Nashriyotchi nomi
+	key       ed25519.PublicKey // Ochiq kalit
+}
+
+// TrustStore - qadalgan (pinned) nashriyotchi ochiq kalitlari ombori
+type TrustStore struct {
+	keys map[string]trustedKey // Kalit ID si -> kalit
+	ids  []string              // Kalitlar tartibi (ID siz imzolarni tekshirish uchun)
+}
+
+// NewTrustStore - saqlangan kalitlar ro‘yxatidan ishonch omborini yaratadi
+func NewTrustStore(keys []models.PublisherKey) (*TrustStore, error) {
+	store := &TrustStore{keys: make(map[string]trustedKey)} // Bo‘sh ombor
+	for _, k := range keys {                                // Har bir kalit bo‘yicha tsikl
+		raw, err := base64.StdEncoding.DecodeString(k.PublicKey) // Kalitni Base64 dan dekod qiladi
+		if err != nil || len(raw) != ed25519.PublicKeySize {     // Kalit hajmi 32 bayt bo‘lishi shart
+			return nil, fmt.Errorf("%w: ID = %s", ErrInvalidKey, k.ID)
+		}
+		if _, ok := store.keys[k.ID]; !ok {
+			store.ids = append(store.ids, k.ID)
+		}
+		store.keys[k.ID] = trustedKey{publisher: k.Publisher, key: ed25519.PublicKey(raw)}
+	}
+	return store, nil
+}
+
+// Len - ombordagi kalitlar soni
+func (t *TrustStore) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.keys)
+}
+
+// verify - xesh ustidagi imzoni tekshiradi va nashriyotchi nomini qaytaradi.
+// Ombor bo‘sh bo‘lsa (kalitlar qadalmagan), paket imzosidan qat'i nazar tekshiruvsiz qabul qilinadi:
+// server imzolashni boshlaganda kalitsiz foydalanuvchilarda o‘rnatish buzilmasligi uchun.
+func (t *TrustStore) verify(keyID, signature string, digest []byte) (string, error) {
+	if t.Len() == 0 {
+		return "", nil // Tekshiruvsiz (nashriyotchi tasdiqlanmagan)
+	}
+	if signature == "" { // Kalitlar qadalgan bo‘lsa, imzo majburiy
+		return "", ErrSignatureMissing
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature) // Imzoni Base64 dan dekod qiladi
+	if err != nil || len(sig) != ed25519.SignatureSize {   // Imzo hajmi 64 bayt bo‘lishi shart
+		return "", ErrSignatureInvalid
+	}
+
+	if keyID != "" { // Kalit ID si ko‘rsatilgan bo‘lsa, faqat shu kalit bilan tekshiradi
+		k, ok := t.keys[keyID]
+		if !ok {
+			return "", fmt.Errorf("%w: ID = %s", ErrUnknownPublisher, keyID)
+		}
+		if !ed25519.Verify(k.key, digest, sig) {
+			return "", ErrSignatureInvalid
+		}
+		return k.publisher, nil
+	}
+
+	for _, id := range t.ids { // ID ko‘rsatilmagan bo‘lsa, barcha kalitlarni sinab ko‘radi
+		if k := t.keys[id]; ed25519.Verify(k.key, digest, sig) {
+			return k.publisher, nil
+		}
+	}
+	return "", ErrSignatureInvalid
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"main/models"
+	"testing"
+)
+
+// testKey - sinov uchun nashriyotchi kaliti (seed dan, natija takrorlanuvchi bo‘lishi uchun)
+func testKey(seed byte) (ed25519.PrivateKey, string) {
+	priv := ed25519.NewKeyFromSeed(bytesOf(seed, ed25519.SeedSize))
+	return priv, base64.StdEncoding.EncodeToString(priv.Public().(ed25519.PublicKey))
+}
+
+func bytesOf(b byte, n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = b
+	}
+	return out
+}
+
+func sign(priv ed25519.PrivateKey, digest []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(priv, digest))
+}
+
+func TestTrustStoreVerify(t *testing.T) {
+	releasePriv, releasePub := testKey(1)
+	otherPriv, otherPub := testKey(2)
+	strangerPriv, _ := testKey(3)
+	store, err := NewTrustStore([]models.PublisherKey{
+		{ID: "release", Publisher: "Release", PublicKey: releasePub},
+		{ID: "other", Publisher: "Other", PublicKey: otherPub},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, _ := NewTrustStore(nil)
+
+	digest := sha256.New().Sum(nil)
+	tampered := append([]byte(nil), digest...)
+	tampered[0] ^= 0xff
+
+	tests := []struct {
+		name          string
+		store         *TrustStore
+		keyID, sig    string
+		digest        []byte
+		wantPublisher string
+		wantErr       error
+	}{
+		{"to'g'ri imzo", store, "release", sign(releasePriv, digest), digest, "Release", nil},
+		{"ID siz imzo", store, "", sign(otherPriv, digest), digest, "Other", nil},
+		{"imzo yo'q, kalitlar qadalgan", store, "release", "", digest, "", ErrSignatureMissing},
+		{"noma'lum kalit ID si", store, "unknown", sign(releasePriv, digest), digest, "", ErrUnknownPublisher},
+		{"boshqa kalit imzosi", store, "release", sign(otherPriv, digest), digest, "", ErrSignatureInvalid},
+		{"begona kalit, ID siz", store, "", sign(strangerPriv, digest), digest, "", ErrSignatureInvalid},
+		{"o'zgartirilgan xesh", store, "release", sign(releasePriv, digest), tampered, "", ErrSignatureInvalid},
+		{"base64 emas", store, "release", "!!!", digest, "", ErrSignatureInvalid},
+		{"qisqa imzo", store, "release", base64.StdEncoding.EncodeToString([]byte("short")), digest, "", ErrSignatureInvalid},
+		{"bo'sh ombor, imzosiz", empty, "", "", digest, "", nil},
+		{"bo'sh ombor, imzo bor", empty, "release", sign(releasePriv, digest), digest, "", nil},
+		{"bo'sh ombor, buzilgan imzo", empty, "release", "!!!", digest, "", nil},
+		{"nil ombor, imzo bor", nil, "release", sign(releasePriv, digest), digest, "", nil},
+		{"nil ombor, imzosiz", nil, "", "", digest, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher, err := tt.store.verify(tt.keyID, tt.sig, tt.digest)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("xatolik = %v, kutilgan %v", err, tt.wantErr)
+			}
+			if publisher != tt.wantPublisher {
+				t.Errorf("nashriyotchi = %q, kutilgan %q", publisher, tt.wantPublisher)
+			}
+		})
+	}
+}
+
+func TestNewTrustStoreInvalidKey(t *testing.T) {
+	for _, key := range []string{"!!!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := NewTrustStore([]models.PublisherKey{{ID: "bad", PublicKey: key}}); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("NewTrustStore(%q) = %v, kutilgan ErrInvalidKey", key, err)
+		}
+	}
+}
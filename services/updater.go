@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"       // Tekshiruvni to‘xtatish uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Jurnal yozuvlarini formatlash uchun
+	"io"            // Xabarlar chiqishi uchun
+	"main/models"   // Dastur tuzilmalari
+	"main/storage"  // O‘rnatilgan dasturlar ro‘yxati
+	"main/version"  // Versiyalarni taqqoslash uchun
+	"os"            // Jurnal fayliga yozish uchun
+	"path/filepath" // Jurnal papkasi uchun
+	"sync"          // Oxirgi natijani himoyalash uchun
+	"time"          // Tekshiruv oralig‘i uchun
+)
+
+// Avtomatik yangilashdagi xatoliklar
+var (
+	ErrUpdateLog  = errors.New("yangilash jurnaliga yozib bo'lmadi") // Jurnalni ochib yoki yozib bo‘lmadi
+	ErrAppRunning = errors.New("dastur ishlab turibdi")              // Yangi versiya ishlab turgan dastur ustiga qo‘yilmaydi
+)
+
+// DefaultUpdateLogPath - avtomatik yangilash urinishlari yoziladigan jurnal fayli (holat papkasida,
+// ishga tushirilgan papkaga bog‘liq emas). Holat papkasi aniqlanmasa, bo‘sh - jurnal yozilmaydi.
+func DefaultUpdateLogPath() string {
+	dir, err := storage.StateDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "auto_update.log")
+}
+
+// Update - o‘rnatilgan dastur va katalogdagi uning yangiroq versiyasi
+type Update struct {
+	Installed models.DownloadedSoftware // Ro‘yxatdagi yozuv
+	Available models.Software           // Katalogdagi yangi versiya
+}
+
+// Updater - katalogni belgilangan oraliqda tekshirib, o‘rnatilgan dasturlar uchun yangilanishlarni topadi.
+// Har bir dasturning siyosatiga qarab: manual - hech narsa qilinmaydi, notify - OnUpdates chaqiriladi,
+// auto - dastur ishlamayotgan bo‘lsa, navbat orqali yangilanadi (har bir urinish jurnalga yoziladi).
+type Updater struct {
+	Interval  time.Duration          // Tekshiruvlar oralig‘i (0 yoki manfiy - fonda tekshirilmaydi)
+	OnUpdates func(updates []Update) // Yangi yangilanishlar topilganda chaqiriladi (har bir versiya haqida bir marta)
+	OnError   func(errs []error)     // Tekshiruvda yoki jurnalga yozishda xatolik bo‘lsa chaqiriladi (nil bo‘lishi mumkin)
+	LogPath   string                 // Avtomatik yangilash jurnali (bo‘sh bo‘lsa, yozilmaydi)
+	Log       io.Writer              // OnError bo‘lmasa, jurnal xatoliklari shu yerga yoziladi (nil bo‘lsa, os.Stderr)
+
+	queue    *Queue
+	mu       sync.Mutex
+	logMu    sync.Mutex        // Jurnal qatorlari aralashib ketmasligi uchun
+	updates  []Update          // Oxirgi tekshiruv natijasi
+	notified map[string]string // Xabar berilgan yangilanishlar: dastur ID si -> versiya
+}
+
+// NewUpdater - navbat orqali yangilaydigan tekshiruvchini yaratadi
+func NewUpdater(queue *Queue, interval time.Duration) *Updater {
+	return &Updater{
+		Interval: interval,
+		LogPath:  DefaultUpdateLogPath(),
+		queue:    queue,
+		notified: make(map[string]string),
+	}
+}
+
+// Check - katalogni so‘rab, yangilanishi mavjud o‘rnatilgan dasturlarni qaytaradi.
+// Ba'zi manbalar ishlamasa, qolganlari bo‘yicha natija va xatoliklar birga qaytariladi.
+func (u *Updater) Check(ctx context.Context) ([]Update, []error) {
+	installer := u.queue.Installer()
+	catalog, errs := installer.FetchCatalog(ctx) // Har bir dastur uchun uning kanalidagi versiya
+	if len(catalog) == 0 {
+		return nil, errs
+	}
+	installed, err := storage.LoadDownloadedSoftware(installer.RegistryPath)
+	if err != nil {
+		return nil, append(errs, err)
+	}
+
+	available := make(map[string]models.Software, len(catalog))
+	for _, software := range catalog {
+		available[software.ID] = software
+	}
+	var updates []Update
+	for _, record := range installed {
+		software, ok := available[record.ID]
+		if !ok || version.Check(record.Version, software.Version) != version.UpdateAvailable {
+			continue
+		}
+		updates = append(updates, Update{Installed: record, Available: software})
+	}
+
+	u.mu.Lock()
+	u.updates = updates
+	u.mu.Unlock()
+	return updates, errs
+}
+
+// Updates - oxirgi tekshiruvda topilgan yangilanishlar
+func (u *Updater) Updates() []Update {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]Update(nil), u.updates...)
+}
+
+// Run - ctx bekor qilinguncha katalogni darhol va keyin har Interval da tekshiradi.
+// Interval musbat bo‘lmasa, hech narsa qilmaydi.
+func (u *Updater) Run(ctx context.Context) {
+	if u.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(u.Interval)
+	defer ticker.Stop()
+	for {
+		u.checkAndNotify(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkAndNotify - siyosati auto bo‘lgan dasturlarni yangilaydi, notify bo‘lganlaridan
+// hali xabar berilmaganlarini OnUpdates ga yuboradi
+func (u *Updater) checkAndNotify(ctx context.Context) {
+	updates, errs := u.Check(ctx)
+	if len(errs) > 0 && u.OnError != nil && ctx.Err() == nil {
+		u.OnError(errs)
+	}
+
+	var fresh, auto []Update
+	u.mu.Lock()
+	for _, update := range updates {
+		switch models.NormalizePolicy(update.Installed.UpdatePolicy) {
+		case models.PolicyManual: // Foydalanuvchi o‘zi yangilaydi
+			continue
+		case models.PolicyAuto:
+			auto = append(auto, update)
+			continue
+		}
+		if u.notified[update.Installed.ID] != update.Available.Version {
+			u.notified[update.Installed.ID] = update.Available.Version
+			fresh = append(fresh, update)
+		}
+	}
+	u.mu.Unlock()
+	for _, update := range auto {
+		u.autoUpdate(update)
+	}
+	if len(fresh) > 0 && u.OnUpdates != nil {
+		u.OnUpdates(fresh)
+	}
+}
+
+// autoUpdate - dastur ishlamayotgan bo‘lsa, uni navbat orqali yangilaydi; har bir urinish jurnalga yoziladi
+func (u *Updater) autoUpdate(update Update) {
+	record, available := update.Installed, update.Available
+	if u.queue.Active(record.ID) != nil { // Oldingi urinish hali tugamagan
+		u.logf("%s %s -> %s: o'tkazib yuborildi, navbatda tugallanmagan vazifa bor", record.ID, record.Version, available.Version)
+		return
+	}
+	running, err := IsRunning(record)
+	switch {
+	case err != nil: // Jarayonni tekshirib bo‘lmasa, xavf qilinmaydi
+		u.logf("%s %s -> %s: o'tkazib yuborildi, jarayonni tekshirib bo'lmadi: %v", record.ID, record.Version, available.Version, err)
+		return
+	case running:
+		u.logf("%s %s -> %s: o'tkazib yuborildi, dastur ishlab turibdi", record.ID, record.Version, available.Version)
+		return
+	}
+
+	u.logf("%s %s -> %s: yangilash boshlandi", record.ID, record.Version, available.Version)
+	u.queue.EnqueueGuarded(JobUpdate, available, notRunning).Watch(func(info JobInfo) {
+		switch info.State {
+		case JobDone:
+			u.logf("%s %s -> %s: yangilandi", record.ID, record.Version, info.Installed.Version)
+		case JobFailed, JobCanceled:
+			u.logf("%s %s -> %s: %s: %v", record.ID, record.Version, available.Version, info.State, info.Err)
+		}
+	})
+}
+
+// notRunning - yuklash davomida dastur ishga tushirilgan bo‘lsa, almashtirishni to‘xtatadi
+func notRunning(record models.DownloadedSoftware) error {
+	running, err := IsRunning(record)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%w: %s - %v", ErrAppRunning, record.ID, err) // Tekshirib bo‘lmasa, xavf qilinmaydi
+	case running:
+		return fmt.Errorf("%w: %s", ErrAppRunning, record.ID)
+	}
+	return nil
+}
+
+// logf - avtomatik yangilash jurnaliga vaqt bilan bitta qator qo‘shadi.
+// Jurnalni ochib yoki yozib bo‘lmasa, xatolik (qator bilan birga) OnError ga, u bo‘lmasa Log ga yuboriladi.
+func (u *Updater) logf(format string, args ...interface{}) {
+	if u.LogPath == "" {
+		return
+	}
+	line := time.Now().Format("2006-01-02 15:04:05") + " " + fmt.Sprintf(format, args...)
+	err := u.appendLog(line + "\n")
+	if err == nil {
+		return
+	}
+	err = fmt.Errorf("%w: %s - %v (%s)", ErrUpdateLog, u.LogPath, err, line)
+	if u.OnError != nil {
+		u.OnError([]error{err})
+		return
+	}
+	out := u.Log
+	if out == nil {
+		out = os.Stderr
+	}
+	fmt.Fprintln(out, "Xatolik:", err)
+}
+
+// appendLog - qatorni jurnal fayli oxiriga yozadi (papka bo‘lmasa, yaratiladi)
+func (u *Updater) appendLog(line string) error {
+	u.logMu.Lock()
+	defer u.logMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(u.LogPath), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(u.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(line); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// UpdateOutdated - katalogni qayta tekshirib, yangilanishi mavjud barcha dasturlarni navbatga qo‘shadi.
+// Bir vaqtda nechta yangilash bajarilishi navbatdagi ishchilar soni bilan cheklanadi.
+func (u *Updater) UpdateOutdated(ctx context.Context) ([]*Job, []error) {
+	updates, errs := u.Check(ctx)
+	if len(updates) == 0 {
+		return nil, errs
+	}
+	return u.UpdateAll(), errs
+}
+
+// UpdateAll - oxirgi tekshiruvda topilgan barcha yangilanishlarni navbatga qo‘shadi
+func (u *Updater) UpdateAll() []*Job {
+	updates := u.Updates()
+	jobs := make([]*Job, 0, len(updates))
+	for _, update := range updates {
+		jobs = append(jobs, u.queue.Enqueue(JobUpdate, update.Available))
+	}
+	return jobs
+}
+
+// BatchResult - bir nechta vazifaning yakuniy natijalari
+type BatchResult struct {
+	Done   []JobInfo // Muvaffaqiyatli tugaganlar
+	Failed []JobInfo // Xatolik bilan tugagan yoki bekor qilinganlar
+}
+
+// WaitJobs - barcha vazifalar tugashini kutib, natijalarni qo‘shilgan tartibda qaytaradi
+func WaitJobs(jobs []*Job) BatchResult {
+	var result BatchResult
+	for _, job := range jobs {
+		done := make(chan JobInfo, 1)
+		stop := job.Watch(func(info JobInfo) {
+			if info.State.Finished() {
+				select {
+				case done <- info:
+				default:
+				}
+			}
+		})
+		info := <-done
+		stop()
+		if info.State == JobDone {
+			result.Done = append(result.Done, info)
+		} else {
+			result.Failed = append(result.Failed, info)
+		}
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"crypto/sha256" // SHA-256 nazorat yig‘indisini hisoblash uchun
+	"encoding/hex"  // Yig‘indini hex satrga aylantirish uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"io"            // Faylni o‘qish uchun
+	"os"            // Fayl tizimi bilan ishlash uchun
+	"strings"       // Satrlar bilan ishlash uchun
+)
+
+// Paket butunligini tekshirishdagi xatoliklar
+var (
+	ErrChecksumMismatch = errors.New("paketning SHA-256 nazorat yig'indisi mos kelmadi") // Paket buzilgan yoki o‘zgartirilgan
+	ErrSizeMismatch     = errors.New("paket hajmi mos kelmadi")                          // Paket chala yuklangan
+)
+
+// hashFile - faylning SHA-256 yig‘indisi (hex) va hajmini hisoblaydi
+func hashFile(filePath string) (string, int64, error) {
+	file, err := os.Open(filePath) // Faylni o‘qish uchun ochadi
+	if err != nil {                // Agar xatolik bo‘lsa
+		return "", 0, fmt.Errorf("faylni ochishda xatolik: %v", err)
+	}
+	defer file.Close() // Funksiya tugagach, faylni yopadi
+
+	h := sha256.New()             // Yangi SHA-256 hisoblagich
+	size, err := io.Copy(h, file) // Faylni bo‘laklab o‘qib, yig‘indini hisoblaydi
+	if err != nil {               // Agar o‘qishda xatolik bo‘lsa
+		return "", 0, fmt.Errorf("faylni o'qishda xatolik: %v", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// verifyArchive - yuklangan arxivni katalogdagi (opts) va server javobidagi (resp) yig‘indi va hajm bilan solishtiradi.
+// Ikkala manbada ham qiymat bo‘lsa, ikkalasi ham mos kelishi shart. So‘ngra nashriyotchi imzosini tekshiradi
+// va tasdiqlangan nashriyotchi nomini qaytaradi.
+func verifyArchive(zipFilePath string, opts DownloadOptions, resp DownloadResponse) (string, error) {
+	sum, size, err := hashFile(zipFilePath) // Haqiqiy yig‘indi va hajmni hisoblaydi
+	if err != nil {
+		return "", err
+	}
+
+	for _, expected := range []string{opts.SHA256, resp.SHA256} { // Katalog va server yig‘indilari bo‘yicha tsikl
+		if expected != "" && !strings.EqualFold(strings.TrimSpace(expected), sum) {
+			return "", fmt.Errorf("%w: kutilgan %s, olingan %s", ErrChecksumMismatch, expected, sum)
+		}
+	}
+	for _, expected := range []int64{opts.Size, resp.Size} { // Katalog va server hajmlari bo‘yicha tsikl
+		if expected > 0 && expected != size {
+			return "", fmt.Errorf("%w: kutilgan %d bayt, olingan %d bayt", ErrSizeMismatch, expected, size)
+		}
+	}
+
+	digest, _ := hex.DecodeString(sum)                                // Imzo xeshning xom baytlari ustidan qo‘yiladi
+	return opts.TrustStore.verify(resp.KeyID, resp.Signature, digest) // Nashriyotchi imzosini tekshiradi
+}
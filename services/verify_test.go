@@ -0,0 +1,61 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"main/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerifyArchive(t *testing.T) {
+	content := []byte("PK\x03\x04 sinov arxivi")
+	zipPath := filepath.Join(t.TempDir(), "app.zip")
+	if err := os.WriteFile(zipPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	raw := sha256.Sum256(content)
+	sum, digest, size := hex.EncodeToString(raw[:]), raw[:], int64(len(content))
+
+	priv, pub := testKey(1)
+	otherPriv, _ := testKey(2)
+	pinned, err := NewTrustStore([]models.PublisherKey{{ID: "release", Publisher: "Release", PublicKey: pub}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongSum := strings.Repeat("0", 64)
+
+	tests := []struct {
+		name          string
+		opts          DownloadOptions
+		resp          DownloadResponse
+		wantPublisher string
+		wantErr       error
+	}{
+		{"tekshiruvsiz", DownloadOptions{}, DownloadResponse{}, "", nil},
+		{"katalog yig'indisi mos", DownloadOptions{SHA256: strings.ToUpper(sum), Size: size}, DownloadResponse{}, "", nil},
+		{"katalog yig'indisi mos emas", DownloadOptions{SHA256: wrongSum}, DownloadResponse{SHA256: sum}, "", ErrChecksumMismatch},
+		{"server yig'indisi mos emas", DownloadOptions{SHA256: sum}, DownloadResponse{SHA256: wrongSum}, "", ErrChecksumMismatch},
+		{"hajm mos emas", DownloadOptions{Size: size + 1}, DownloadResponse{}, "", ErrSizeMismatch},
+		{"server hajmi mos emas", DownloadOptions{}, DownloadResponse{Size: size - 1}, "", ErrSizeMismatch},
+		{"imzo to'g'ri", DownloadOptions{TrustStore: pinned}, DownloadResponse{KeyID: "release", Signature: sign(priv, digest)}, "Release", nil},
+		{"imzo yo'q", DownloadOptions{TrustStore: pinned}, DownloadResponse{SHA256: sum}, "", ErrSignatureMissing},
+		{"noma'lum kalit", DownloadOptions{TrustStore: pinned}, DownloadResponse{KeyID: "other", Signature: sign(otherPriv, digest)}, "", ErrUnknownPublisher},
+		{"noto'g'ri imzo", DownloadOptions{TrustStore: pinned}, DownloadResponse{KeyID: "release", Signature: sign(otherPriv, digest)}, "", ErrSignatureInvalid},
+		{"yig'indi imzodan oldin tekshiriladi", DownloadOptions{SHA256: wrongSum, TrustStore: pinned}, DownloadResponse{KeyID: "release", Signature: sign(priv, digest)}, "", ErrChecksumMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher, err := verifyArchive(zipPath, tt.opts, tt.resp)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("xatolik = %v, kutilgan %v", err, tt.wantErr)
+			}
+			if publisher != tt.wantPublisher {
+				t.Errorf("nashriyotchi = %q, kutilgan %q", publisher, tt.wantPublisher)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"encoding/json" // JSON ma'lumotlarni dekodlash uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"main/models"   // `models` paketidagi tuzilmalarni ishlatish uchun
+	"os"            // Fayl tizimi bilan ishlash uchun
+	"path/filepath" // Standart fayl yo‘lini yig‘ish uchun
+)
+
+// LegacyTrustedKeysPath - eski versiyalar kalitlarni joriy papkadagi shu fayldan o‘qigan
+const LegacyTrustedKeysPath = "trusted_keys.json"
+
+// ErrNoTrustedKeys - kalitlar fayli majburiy, lekin unda birorta ham kalit yo‘q
+var ErrNoTrustedKeys = errors.New("ishonchli kalitlar fayli bo'sh")
+
+// DefaultTrustedKeysPath - ishonchli kalitlarning standart fayli: sozlamalar papkasida
+// (masalan ~/.config/appStore/trusted_keys.json), u aniqlanmasa holat papkasida.
+// Ikkalasi ham aniqlanmasa, bo‘sh - joriy papkadagi fayl o‘qilmaydi.
+func DefaultTrustedKeysPath() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "appStore", "trusted_keys.json")
+	}
+	if dir, err := StateDir(); err == nil {
+		return filepath.Join(dir, "trusted_keys.json")
+	}
+	return ""
+}
+
+// JSON fayldan ishonchli nashriyotchi kalitlarini o'qish funksiyasi.
+// required bo‘lsa (fayl sozlamalarda aniq ko‘rsatilgan), fayl yo‘qligi yoki bo‘shligi xatolik hisoblanadi:
+// aks holda imzo tekshiruvi jimgina o‘chib qolardi.
+func LoadTrustedKeys(filePath string, required bool) ([]models.PublisherKey, error) {
+	var keys []models.PublisherKey   // Kalitlar ro‘yxati uchun bo‘sh massiv
+	if filePath == "" && !required { // Standart fayl yo‘li aniqlanmagan
+		return keys, nil
+	}
+
+	data, err := os.ReadFile(filePath) // Faylni to‘liq o‘qiydi (kalitlar fayli kichik)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			// Fayl mavjud bo‘lmasa, bo‘sh ro‘yxat qaytaradi (imzo tekshiruvi ixtiyoriy bo‘ladi)
+			return keys, nil
+		}
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s - %v", ErrFileNotFound, filePath, err)
+		}
+		return nil, fmt.Errorf("%w: %s - %v", ErrFileOpenFailed, filePath, err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &keys); err != nil { // JSON dan kalitlarni dekod qiladi
+			return nil, fmt.Errorf("%w: %s - %v", ErrJSONDecodeFailed, filePath, err)
+		}
+	}
+	if len(keys) == 0 && required { // Bo‘sh fayl yoki "[]"
+		return nil, fmt.Errorf("%w: %s", ErrNoTrustedKeys, filePath)
+	}
+	return keys, nil // Kalitlar ro‘yxatini qaytaradi
+}
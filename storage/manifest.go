@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"encoding/json" // JSON manifestlar uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"main/models"   // `models` paketidagi tuzilmalarni ishlatish uchun
+	"os"            // Faylni o‘qish uchun
+	"path/filepath" // Fayl kengaytmasini aniqlash uchun
+	"strings"       // Kengaytmani solishtirish uchun
+
+	"gopkg.in/yaml.v3" // YAML manifestlar uchun
+)
+
+// ErrInvalidManifest - manifest o‘qildi, lekin mazmuni noto‘g‘ri
+var ErrInvalidManifest = errors.New("noto'g'ri manifest")
+
+// LoadManifest - YAML (.yaml, .yml) yoki JSON manifestni o‘qiydi va tekshiradi
+func LoadManifest(filePath string) (*models.Manifest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s - %v", ErrFileOpenFailed, filePath, err)
+	}
+
+	var manifest models.Manifest
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("%w: %s - %v", ErrInvalidManifest, filePath, err)
+		}
+	default:
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("%w: %s - %v", ErrInvalidManifest, filePath, err)
+		}
+	}
+
+	seen := make(map[string]bool) // Bir dastur ikki marta ko‘rsatilmasligi uchun
+	for i, app := range manifest.Apps {
+		switch {
+		case app.ID == "":
+			return nil, fmt.Errorf("%w: %s - %d-dastur ID si bo'sh", ErrInvalidManifest, filePath, i+1)
+		case seen[app.ID]:
+			return nil, fmt.Errorf("%w: %s - %s ikki marta ko'rsatilgan", ErrInvalidManifest, filePath, app.ID)
+		case app.Channel != "" && models.ChannelRank(app.Channel) < 0:
+			return nil, fmt.Errorf("%w: %s - %s: noma'lum kanal %q", ErrInvalidManifest, filePath, app.ID, app.Channel)
+		case app.Policy != "" && !models.ValidPolicy(app.Policy):
+			return nil, fmt.Errorf("%w: %s - %s: noma'lum siyosat %q", ErrInvalidManifest, filePath, app.ID, app.Policy)
+		}
+		seen[app.ID] = true
+	}
+	return &manifest, nil
+}
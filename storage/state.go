@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"encoding/json" // Ro‘yxatni yangi joyga yozish uchun
+	"errors"        // Maxsus xatoliklarni yaratish uchun
+	"fmt"           // Xatolik xabarlarini formatlash uchun
+	"os"            // Fayllar va muhit o‘zgaruvchilari uchun
+	"path/filepath" // Holat papkasi yo‘llari uchun
+	"runtime"       // Operatsion tizimni aniqlash uchun
+)
+
+// LegacyRegistryPath - eski versiyalar ro‘yxatni joriy papkadagi shu faylda saqlagan
+const LegacyRegistryPath = "downloaded_software.json"
+
+// ErrStateDir - foydalanuvchi holat papkasini aniqlab bo‘lmadi (masalan, uy papkasi yo‘q)
+var ErrStateDir = errors.New("holat papkasi aniqlanmadi")
+
+// StateDir - foydalanuvchi holat fayllari papkasi: Linux da $XDG_STATE_HOME/appstore
+// (o‘zgaruvchi bo‘lmasa ~/.local/state/appstore), Windows da %LOCALAPPDATA%\appstore,
+// macOS da ~/Library/Application Support/appstore
+func StateDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		dir, err := os.UserCacheDir() // Windows da %LOCALAPPDATA%, macOS da ~/Library/Caches
+		if err != nil {
+			return "", err
+		}
+		if runtime.GOOS == "darwin" {
+			dir = filepath.Join(filepath.Dir(dir), "Application Support") // Keshdan farqli, o‘chirib yuborilmaydi
+		}
+		return filepath.Join(dir, "appstore"), nil
+	}
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) { // Nisbiy yo‘l e'tiborsiz qoldiriladi
+		return filepath.Join(dir, "appstore"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "appstore"), nil
+}
+
+// DefaultRegistryPath - o‘rnatilgan dasturlar ro‘yxatining standart fayli (ishga tushirilgan papkaga bog‘liq emas).
+// Holat papkasi aniqlanmasa, ErrStateDir qaytariladi: joriy papkadagi eski faylga qaytilmaydi.
+func DefaultRegistryPath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrStateDir, err)
+	}
+	return filepath.Join(dir, "downloaded_software.json"), nil
+}
+
+// ImportLegacyRegistry - ro‘yxat fayli hali yo‘q bo‘lsa, joriy papkadagi eski ro‘yxatni unga ko‘chirib yozadi.
+// Yangi fayl paydo bo‘lgach, import qayta bajarilmaydi; eski fayl o‘zgartirilmaydi.
+// Ko‘chirilgan yozuvlar sonini qaytaradi.
+func ImportLegacyRegistry(filePath string) (int, error) {
+	legacy, target, ok := legacyImport(LegacyRegistryPath, filePath)
+	if !ok {
+		return 0, nil
+	}
+	softwares, err := LoadDownloadedSoftware(legacy)
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(softwares)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s - %v", ErrJSONEncodeFailed, target, err)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if err := writeNewFile(target, append(data, '\n')); err != nil {
+		return 0, err
+	}
+	return len(softwares), nil
+}
+
+// ImportLegacyTrustedKeys - kalitlar fayli hali yo‘q bo‘lsa, joriy papkadagi eski trusted_keys.json ni
+// unga ko‘chirib yozadi (qadalgan kalitlar yangi joyda ham ishlashi uchun). Ko‘chirilgan kalitlar sonini qaytaradi.
+func ImportLegacyTrustedKeys(filePath string) (int, error) {
+	legacy, target, ok := legacyImport(LegacyTrustedKeysPath, filePath)
+	if !ok {
+		return 0, nil
+	}
+	keys, err := LoadTrustedKeys(legacy, false) // Buzilgan fayl ko‘chirilmaydi
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s - %v", ErrJSONEncodeFailed, target, err)
+	}
+	if err := writeNewFile(target, append(data, '\n')); err != nil {
+		return 0, err
+	}
+	return len(keys), nil
+}
+
+// legacyImport - joriy papkadagi eski fayl va yangi fayl mutlaq yo‘llari; import faqat eski fayl bor,
+// yangisi esa hali yo‘q (va ular bitta fayl emas) bo‘lsa kerak
+func legacyImport(legacyPath, filePath string) (legacy, target string, ok bool) {
+	legacy, err := filepath.Abs(legacyPath)
+	if err != nil {
+		return "", "", false
+	}
+	target, err = filepath.Abs(filePath)
+	if err != nil || filePath == "" || target == legacy {
+		return "", "", false
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) { // Yangi fayl allaqachon bor (yoki tekshirib bo‘lmadi)
+		return "", "", false
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		return "", "", false
+	}
+	return legacy, target, true
+}
+
+// writeNewFile - faylni vaqtinchalik nusxa orqali yozadi (papkasi bo‘lmasa, yaratiladi)
+func writeNewFile(target string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrFileCreateFailed, target, err)
+	}
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("%w: %s - %v", ErrFileCreateFailed, target, err)
+	}
+	if err := os.Rename(tmp, target); err != nil { // Yarim yozilgan fayl importni to‘xtatib qo‘ymasligi uchun
+		os.Remove(tmp)
+		return fmt.Errorf("%w: %s - %v", ErrFileCreateFailed, target, err)
+	}
+	return nil
+}
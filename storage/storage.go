@@ -6,8 +6,14 @@ import (
 	"fmt"           // Xatolik xabarlarini formatlash uchun
 	"main/models"   // `models` paketidagi tuzilmalarni ishlatish uchun
 	"os"            // Fayl tizimi bilan ishlash uchun (fayl ochish, yozish)
+	"path/filepath" // Ro‘yxat papkasini yaratish uchun
+	"sync"          // Bir vaqtda ishlayotgan o‘rnatishlar faylni buzmasligi uchun
 )
 
+// registryMu - ro‘yxat faylini o‘qish va qayta yozishni ketma-ket bajaradi
+// (yuklash navbatidagi bir nechta vazifa bir vaqtda yozishi mumkin)
+var registryMu sync.Mutex
+
 // Maxsus xatoliklar
 var (
 	ErrFileNotFound     = errors.New("fayl topilmadi")            // Fayl tizimda mavjud emasligini bildiruvchi xatolik
@@ -20,6 +26,18 @@ var (
 
 // Yuklangan dastur ma'lumotlarini faylga saqlash funksiyasi
 func SaveDownloadedSoftware(software models.DownloadedSoftware, filePath string) error {
+	return UpdateDownloadedSoftware(software.ID, filePath, func(*models.DownloadedSoftware) (models.DownloadedSoftware, error) {
+		return software, nil
+	})
+}
+
+// UpdateDownloadedSoftware - id yozuvini ro‘yxatdan o‘qib, update natijasini saqlaydi; o‘qish va yozish
+// bitta registryMu ostida bajariladi, shuning uchun oraliqdagi boshqa o‘zgarish yo‘qolmaydi.
+// update ga joriy yozuv (bo‘lmasa nil) beriladi; u xatolik qaytarsa, fayl o‘zgarmaydi.
+func UpdateDownloadedSoftware(id string, filePath string, update func(current *models.DownloadedSoftware) (models.DownloadedSoftware, error)) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
 	var softwares []models.DownloadedSoftware // Dasturlar ro‘yxati uchun bo‘sh massiv
 
 	// Fayl mavjudligini tekshirish
@@ -48,19 +66,28 @@ func SaveDownloadedSoftware(software models.DownloadedSoftware, filePath string)
 	}
 
 	// Dasturni yangilash yoki qo‘shish
-	updated := false                     // Dastur yangilanganligini tekshirish uchun flag
-	for i, existing := range softwares { // Mavjud dasturlar bo‘yicha tsikl
-		if existing.ID == software.ID { // Agar ID mos kelsa
-			softwares[i] = software // Mavjud dasturni yangilaydi
-			updated = true          // Yangilanganligini belgilaydi
-			break                   // Tsikldan chiqadi
+	index := -1                            // Mavjud yozuv o‘rni (-1 - yangi dastur)
+	var current *models.DownloadedSoftware // Joriy yozuv
+	for i := range softwares {             // Mavjud dasturlar bo‘yicha tsikl
+		if softwares[i].ID == id { // Agar ID mos kelsa
+			index, current = i, &softwares[i]
+			break // Tsikldan chiqadi
 		}
 	}
-	if !updated { // Agar yangilanmagan bo‘lsa
+	software, err := update(current) // Yangi yozuvni joriy yozuv asosida tayyorlaydi
+	if err != nil {
+		return err
+	}
+	if index >= 0 { // Agar dastur ro‘yxatda bo‘lsa
+		softwares[index] = software // Mavjud dasturni yangilaydi
+	} else {
 		softwares = append(softwares, software) // Yangi dasturni ro‘yxatga qo‘shadi
 	}
 
 	// Faylni qayta yaratish va yozish
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil { // Holat papkasi birinchi saqlashda yaratiladi
+		return fmt.Errorf("%w: %s - %v", ErrFileCreateFailed, filePath, err)
+	}
 	file, err := os.Create(filePath) // Faylni qayta yozish uchun ochadi (ustiga yozadi)
 	if err != nil {                  // Agar fayl yaratishda xatolik bo‘lsa
 		return fmt.Errorf("%w: %s - %v", ErrFileCreateFailed, filePath, err) // Yaratish xatoligi bilan qaytaradi
@@ -76,6 +103,9 @@ func SaveDownloadedSoftware(software models.DownloadedSoftware, filePath string)
 
 // JSON fayldan yuklangan dasturlarni o'qish funksiyasi
 func LoadDownloadedSoftware(filePath string) ([]models.DownloadedSoftware, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
 	var softwares []models.DownloadedSoftware // Dasturlar ro‘yxati uchun bo‘sh massiv
 
 	// Fayl mavjudligini tekshiradi
@@ -115,6 +145,9 @@ func LoadDownloadedSoftware(filePath string) ([]models.DownloadedSoftware, error
 
 // JSON fayldan dasturni o'chirish funksiyasi
 func DeleteSoftware(id string, filePath string) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
 	var softwares []models.DownloadedSoftware // Dasturlar ro‘yxati uchun bo‘sh massiv
 
 	// Fayl mavjudligini tekshirish va o'qish
@@ -163,6 +196,9 @@ func DeleteSoftware(id string, filePath string) error {
 
 // JSON fayldan bitta dasturni ID bo'yicha olish funksiyasi
 func GetSoftwareByID(id string, filePath string) (*models.DownloadedSoftware, error) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
 	var softwares []models.DownloadedSoftware // Dasturlar ro‘yxati uchun bo‘sh massiv
 
 	// Fayl mavjudligini tekshirish va o'qish
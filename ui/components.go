@@ -2,6 +2,7 @@ package ui
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -11,11 +12,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"time"
 
+	"main/config"
 	"main/models"
 	"main/services"
 	"main/storage"
+	"main/version"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -25,7 +27,9 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func SetupUI(myWindow fyne.Window) {
+// SetupUI - katalogni yuklab, dastur kartalarini oynaga joylashtiradi.
+// Barcha o‘rnatish va yangilashlar umumiy queue navbati orqali bajariladi.
+func SetupUI(myWindow fyne.Window, cfg *config.Config, queue *services.Queue) {
 	// Yuklanish xabari uchun yorliq
 	label := widget.NewLabel("Ma'lumot yuklanmoqda...") // "Ma'lumot yuklanmoqda..." matnli yangi yorliq yaratadi
 
@@ -33,8 +37,10 @@ func SetupUI(myWindow fyne.Window) {
 	descriptionLabel := widget.NewLabel("") // Bo‘sh matnli yorliq yaratadi, keyinchalik dastur tavsifi uchun ishlatiladi
 
 	// API dan dasturlarni olish
-	softwares, err := services.FetchAPIData("http://localhost:8080/appStore/getAllSoftware") // API dan dasturlar ro‘yxatini oladi
-	if err != nil {                                                                          // Agar xatolik bo'lsa
+	installer := queue.Installer()                                  // O‘rnatuvchi (ro‘yxat fayli va kanallar)
+	softwares, errs := installer.FetchCatalog(context.Background()) // Barcha manbalardan har bir dasturning kanalidagi versiyani oladi
+	if len(errs) > 0 && len(softwares) == 0 {                       // Agar hech bir manba ishlamagan bo'lsa
+		err := catalogErrors(errs)
 		// Xatolik xabarini yorliqqa yozish
 		label.SetText(fmt.Sprintf("Xatolik: %v", err)) // Xatolik haqida xabar yorliqqa yoziladi
 		// Foydalanuvchiga xatolikni ko‘rsatish
@@ -43,6 +49,9 @@ func SetupUI(myWindow fyne.Window) {
 		myWindow.SetContent(container.NewVBox(label, descriptionLabel))
 		return // Funksiyadan chiqish
 	}
+	if len(errs) > 0 { // Ba'zi manbalar ishlamagan bo‘lsa, qolganlarini ko‘rsatib, ogohlantiradi
+		dialog.ShowError(fmt.Errorf("ba'zi kataloglar yuklanmadi: %v", catalogErrors(errs)), myWindow)
+	}
 
 	// Agar dasturlar bo‘sh bo‘lsa
 	if len(softwares) == 0 {
@@ -64,16 +73,88 @@ func SetupUI(myWindow fyne.Window) {
 		myWindow.SetContent(container.NewVBox(label))
 		// Goroutine ichida qayta yuklash
 		go func() {
-			SetupUI(myWindow) // UI ni qayta yuklaydi
+			SetupUI(myWindow, cfg, queue) // UI ni qayta yuklaydi
 		}()
 	}) // Ikonkali (refresh) tugma yaratadi
 
-	// Qidiruv maydoni va tugmani joylashtirish
-	searchContainer := container.NewBorder(nil, nil, nil, reloadButton, searchEntry) // Qidiruv maydoni va tugmani o‘ng tarafda joylashtiradi
+	// Umumiy reliz kanali (dastur uchun alohida tanlanmagan bo‘lsa)
+	channelSelect := widget.NewSelect(models.Channels, nil)
+	channelSelect.SetSelected(cfg.Channel)
+	channelSelect.OnChanged = func(channel string) {
+		if channel == cfg.Channel {
+			return
+		}
+		cfg.Channel = channel // Joriy seans uchun; doimiy qilish uchun sozlamalar fayli yoki -channel
+		label.SetText("Ma'lumot qayta yuklanmoqda...")
+		myWindow.SetContent(container.NewVBox(label))
+		go SetupUI(myWindow, cfg, queue) // Katalogni yangi kanal bilan qayta yuklaydi
+	}
+
+	// "Yuklamalar" tugmasi (navbatdagi, bajarilayotgan va tugagan vazifalar)
+	downloadsButton := widget.NewButtonWithIcon("", theme.DownloadIcon(), func() {
+		showDownloads(queue) // Yuklamalar oynasini ochadi
+	})
+
+	// "Hammasini yangilash" tugmasi (yangilanishi mavjud barcha o‘rnatilgan dasturlar)
+	var updateAllButton *widget.Button
+	updateAllButton = widget.NewButtonWithIcon("", theme.MediaFastForwardIcon(), func() {
+		updateAllButton.Disable() // Yangilashlar tugaguncha qayta bosilmaydi
+		go func() {
+			defer updateAllButton.Enable()
+			updateAll(myWindow, cfg, queue)
+		}()
+	})
+
+	// Qidiruv maydoni va tugmalarni joylashtirish
+	toolbar := container.NewHBox(channelSelect, downloadsButton, updateAllButton, reloadButton) // Asboblar paneli tugmalari
+	searchContainer := container.NewBorder(nil, nil, nil, toolbar, searchEntry)                 // Qidiruv maydoni va tugmalarni o‘ng tarafda joylashtiradi
 
 	// Dastur kartalari uchun grid konteyner (150x140 o'lchamli)
 	contentContainer := container.NewGridWrap(fyne.NewSize(150, 140)) // 150x140 o‘lchamdagi grid konteyner yaratadi
 
+	// Tanlangan dastur kanali va yangilash siyosati (faqat o‘rnatilgan dasturlar uchun, "Ma’lumot" tugmasi bosilganda ko‘rinadi)
+	const globalChannel = "umumiy"
+	appChannelSelect := widget.NewSelect(append([]string{globalChannel}, models.Channels...), nil)
+	appPolicySelect := widget.NewSelect(models.Policies, nil) // manual, notify yoki auto
+	appChannelBox := container.NewHBox(widget.NewLabel("Kanal:"), appChannelSelect, widget.NewLabel("Yangilash:"), appPolicySelect)
+	appChannelBox.Hide()
+	showAppChannel := func(software models.Software) { // Dastur uchun kanal tanlovini ko‘rsatadi
+		record, err := storage.GetSoftwareByID(software.ID, installer.RegistryPath)
+		if err != nil { // O‘rnatilmagan dastur umumiy kanaldan o‘rnatiladi
+			appChannelBox.Hide()
+			return
+		}
+		appChannelSelect.OnChanged = nil
+		if record.Channel == "" {
+			appChannelSelect.SetSelected(globalChannel)
+		} else {
+			appChannelSelect.SetSelected(record.Channel)
+		}
+		appChannelSelect.OnChanged = func(channel string) {
+			if channel == globalChannel {
+				channel = ""
+			}
+			if err := installer.SetChannel(software.ID, channel); err != nil {
+				dialog.ShowError(fmt.Errorf("kanalni saqlashda xatolik: %v", err), myWindow)
+				return
+			}
+			label.SetText("Ma'lumot qayta yuklanmoqda...")
+			myWindow.SetContent(container.NewVBox(label))
+			go SetupUI(myWindow, cfg, queue) // Dastur yangi kanal bo‘yicha qayta tekshiriladi
+		}
+		appPolicySelect.OnChanged = nil
+		appPolicySelect.SetSelected(models.NormalizePolicy(record.UpdatePolicy))
+		appPolicySelect.OnChanged = func(policy string) {
+			if err := installer.SetPolicy(software.ID, policy); err != nil { // Fondagi tekshiruv keyingi safar hisobga oladi
+				dialog.ShowError(fmt.Errorf("yangilash siyosatini saqlashda xatolik: %v", err), myWindow)
+			}
+		}
+		appChannelBox.Show()
+	}
+
+	// Kartalar bir marta yaratiladi, qidiruvda qayta ishlatiladi (yuklash holati yo‘qolmasligi uchun)
+	cards := make(map[string]fyne.CanvasObject)
+
 	// Dastur ro'yxatini yangilash funksiyasi
 	updateSoftwareList := func(query string) {
 		// Avvalgi ob'ektlarni tozalash
@@ -88,8 +169,12 @@ func SetupUI(myWindow fyne.Window) {
 		for _, software := range softwares { // Har bir dastur bo‘yicha tsikl yuritadi
 			// Agar qidiruv bo'sh yoki nom/tavsifda so'z bo'lsa
 			if query == "" || strings.Contains(strings.ToLower(software.Name), query) || strings.Contains(strings.ToLower(software.Description), query) {
-				// Dastur kartasini yaratish
-				card := createSoftwareCard(software, descriptionLabel, myWindow) // Dastur uchun kartani yaratadi
+				// Dastur kartasini olish yoki yaratish
+				card, ok := cards[software.ID]
+				if !ok {
+					card = createSoftwareCard(software, descriptionLabel, showAppChannel, myWindow, queue) // Dastur uchun kartani yaratadi
+					cards[software.ID] = card
+				}
 				// Kartani ro'yxatga qo'shish
 				filteredSoftwares = append(filteredSoftwares, card) // Kartani filtlangan ro‘yxatga qo‘shadi
 			}
@@ -117,7 +202,7 @@ func SetupUI(myWindow fyne.Window) {
 		container.NewPadded(searchContainer), // Qidiruv maydoni + tugmani padding bilan qo‘shadi
 		contentContainer,                     // Dastur kartalari gridini qo‘shadi
 		widget.NewSeparator(),                // Ajratuvchi chiziq qo‘shadi
-		descriptionLabel,                     // Tavsif yorlig‘ini qo‘shadi
+		container.NewBorder(nil, nil, nil, appChannelBox, descriptionLabel), // Tavsif yorlig‘i va dastur kanali
 	)
 
 	// Oynaga asosiy konteynerni joylashtirish
@@ -127,7 +212,7 @@ func SetupUI(myWindow fyne.Window) {
 	myWindow.Canvas().Refresh(mainContainer) // Butun oynani majburiy yangilaydi
 }
 
-func createSoftwareCard(software models.Software, descriptionLabel *widget.Label, myWindow fyne.Window) fyne.CanvasObject {
+func createSoftwareCard(software models.Software, descriptionLabel *widget.Label, onInfo func(models.Software), myWindow fyne.Window, queue *services.Queue) fyne.CanvasObject {
 	// Dastur nomini yorliq sifatida yaratish
 	title := widget.NewLabelWithStyle(software.Name, fyne.TextAlignCenter, fyne.TextStyle{Bold: true}) // Dastur nomini qalin va markazda ko‘rsatadi
 
@@ -165,72 +250,168 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 	openButton.Importance = widget.LowImportance                          // Tugma muhimligini past darajaga qo‘yadi
 	openButton.Hide()                                                     // Tugmani yashiradi
 
-	progressBar := widget.NewProgressBarInfinite() // Cheksiz progress bar yaratadi
-	progressBar.Hide()                             // Progress barni yashiradi
+	progressBar := widget.NewProgressBar()                  // Aniq (foizli) progress bar yaratadi
+	progressBar.TextFormatter = func() string { return "" } // Foiz matnini yashiradi (karta tor)
+	progressBar.Hide()                                      // Progress barni yashiradi
+
+	progressLabel := canvas.NewText("", theme.Color(theme.ColorNameForeground)) // Tezlik va qolgan vaqt yorlig‘i
+	progressLabel.TextSize = 10                                                 // Kichik shrift
+	progressLabel.Hide()                                                        // Yorliqni yashiradi
 
 	updateButton := widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), nil) // "Yangilash" tugmasi (faqat ikonka)
 	updateButton.Hide()                                                        // Tugmani yashiradi
 
+	rollbackButton := widget.NewButtonWithIcon("", theme.ContentUndoIcon(), nil) // "Orqaga qaytarish" tugmasi (faqat ikonka)
+	rollbackButton.Importance = widget.LowImportance                             // Tugma muhimligini past darajaga qo‘yadi
+	rollbackButton.Hide()                                                        // Tugmani yashiradi
+	var previousVersion string                                                   // Qaytarish mumkin bo‘lgan eng yangi avvalgi versiya
+
+	var job *services.Job                                                     // Kartadagi dastur uchun navbatdagi vazifa
+	cancelButton := widget.NewButtonWithIcon("", theme.CancelIcon(), func() { // "Bekor qilish" tugmasi (faqat ikonka)
+		if job != nil {
+			job.Cancel() // Navbatdan olib tashlaydi yoki yuklash va ZIP ochishni to‘xtatadi
+		}
+	})
+	cancelButton.Importance = widget.LowImportance // Tugma muhimligini past darajaga qo‘yadi
+	cancelButton.Hide()                            // Tugmani yashiradi
+
+	var versionInfo string                                                // O‘rnatilgan va katalogdagi versiyalar holati
 	infoButton := widget.NewButtonWithIcon("", theme.InfoIcon(), func() { // "Ma’lumot" tugmasi (faqat ikonka)
-		descriptionLabel.SetText("📌 " + software.Description) // Tugma bosilganda tavsifni ko‘rsatadi
+		descriptionLabel.SetText("📌 " + software.Description + versionInfo) // Tugma bosilganda tavsifni ko‘rsatadi
+		onInfo(software)                                                    // Dastur kanali tanlovini ko‘rsatadi
 	})
 	infoButton.Importance = widget.LowImportance // Tugma muhimligini past darajaga qo‘yadi
 
-	// JSON fayldan yuklangan dasturlarni o‘qish
-	downloadedSoftwares, err := storage.LoadDownloadedSoftware("downloaded_software.json") // Yuklangan dasturlarni JSON dan o‘qiydi
-	if err != nil {
-		dialog.ShowError(fmt.Errorf("JSON o'qishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
+	// Tasdiqlangan nashriyotchi belgisi (ikonka burchagida)
+	verifiedBadge := widget.NewIcon(theme.ConfirmIcon()) // "Tasdiqlangan nashriyotchi" belgisi
+	verifiedBadge.Hide()                                 // Belgini yashiradi
+	newerBadge := widget.NewIcon(theme.WarningIcon())    // "O‘rnatilgan versiya katalogdagidan yangiroq" belgisi
+	newerBadge.Hide()                                    // Belgini yashiradi
+
+	setVerified := func(publisher string) { // Belgini nashriyotchiga qarab ko‘rsatadi yoki yashiradi
+		if publisher == "" {
+			verifiedBadge.Hide()
+			return
+		}
+		verifiedBadge.Show()
 	}
 
-	// Dastur holatini tekshirish
-	var isDownloaded bool                            // Dastur yuklanganligini tekshirish uchun flag
-	var isLatestVersion bool                         // Dastur oxirgi versiyada ekanligini tekshirish uchun flag
-	for _, downloaded := range downloadedSoftwares { // Yuklangan dasturlar bo‘yicha tsikl
-		if downloaded.ID == software.ID { // Agar dastur ID si mos kelsa
-			isDownloaded = true                         // Dastur yuklangan deb belgilanadi
-			if downloaded.Version == software.Version { // Agar versiyalar bir xil bo‘lsa
-				isLatestVersion = true // Oxirgi versiya deb belgilanadi
+	installer := queue.Installer() // O‘rnatish, o‘chirish va ro‘yxat fayli
+
+	// showState - ro‘yxatdagi yozuvga qarab tugmalarni ko‘rsatadi
+	showState := func() {
+		cancelButton.Hide()  // Bekor qiladigan vazifa yo‘q
+		progressBar.Hide()   // Progress barni yashiradi
+		progressLabel.Hide() // Tezlik yorlig‘ini yashiradi
+
+		downloaded, err := storage.GetSoftwareByID(software.ID, installer.RegistryPath) // O‘rnatilgan dastur yozuvi
+		if err != nil {                                                                 // Dastur o‘rnatilmagan bo‘lsa
+			if !errors.Is(err, storage.ErrSoftwareNotFound) {
+				dialog.ShowError(fmt.Errorf("JSON o'qishda xatolik: %v", err), myWindow)
 			}
-			break // Tsikldan chiqadi
+			setVerified("")
+			versionInfo = ""
+			newerBadge.Hide()
+			deleteButton.Hide()
+			openButton.Hide()
+			updateButton.Hide()
+			rollbackButton.Hide()
+			downloadButton.Show() // Faqat "Yuklash" tugmasini ko‘rsatadi
+			return
 		}
-	}
 
-	// Tugmalarni holatga qarab ko‘rsatish
-	if !isDownloaded { // Agar dastur yuklanmagan bo‘lsa
-		downloadButton.Show() // Faqat "Yuklash" tugmasini ko‘rsatadi
-	} else { // Agar dastur yuklangan bo‘lsa
-		deleteButton.Show()   // "O‘chirish" tugmasini ko‘rsatadi
-		openButton.Show()     // "Ochish" tugmasini ko‘rsatadi
-		if !isLatestVersion { // Agar versiya eskirgan bo‘lsa
-			updateButton.Show() // "Yangilash" tugmasini ko‘rsatadi
+		setVerified(downloaded.Publisher) // Tasdiqlangan nashriyotchini ko‘rsatadi
+		downloadButton.Hide()
+		deleteButton.Show()                                           // "O‘chirish" tugmasini ko‘rsatadi
+		openButton.Show()                                             // "Ochish" tugmasini ko‘rsatadi
+		status := version.Check(downloaded.Version, software.Version) // Semver bo‘yicha taqqoslaydi
+		versionInfo = fmt.Sprintf("\nO'rnatilgan: %s, katalogda: %s [%s] (%s)", downloaded.Version, software.Version, software.Channel, status)
+		switch status {
+		case version.UpdateAvailable: // Katalogda yangiroq versiya bor
+			newerBadge.Hide()
+			updateButton.Importance = widget.HighImportance // Yangilanishni ajratib ko‘rsatadi
+			updateButton.Show()                             // "Yangilash" tugmasini ko‘rsatadi
+			updateButton.Refresh()
+		case version.InstalledNewer: // Katalogdagi eski versiya yangilanish sifatida taklif qilinmaydi
+			updateButton.Hide()
+			newerBadge.Show() // "O‘rnatilgan versiya yangiroq" belgisini ko‘rsatadi
+		default: // Eng so‘nggi versiya o‘rnatilgan
+			updateButton.Hide()
+			newerBadge.Hide()
+		}
+		if len(downloaded.Previous) > 0 { // Diskda avvalgi versiya saqlangan bo‘lsa
+			previousVersion = downloaded.Previous[0].Version
+			rollbackButton.Show() // "Orqaga qaytarish" tugmasini ko‘rsatadi
+		} else {
+			rollbackButton.Hide()
 		}
 	}
 
+	// watchJob - navbatdagi vazifa holatini kartada ko‘rsatadi
+	watchJob := func(j *services.Job) {
+		job = j
+		downloadButton.Hide()                                        // "Yuklash" tugmasini yashiradi
+		deleteButton.Hide()                                          // "O‘chirish" tugmasini yashiradi
+		updateButton.Hide()                                          // "Yangilash" tugmasini yashiradi
+		openButton.Hide()                                            // "Ochish" tugmasini yashiradi
+		rollbackButton.Hide()                                        // "Orqaga qaytarish" tugmasini yashiradi
+		newerBadge.Hide()                                            // Versiya belgisini yashiradi
+		progressBar.Show()                                           // Progress barni ko‘rsatadi
+		progressLabel.Show()                                         // Tezlik yorlig‘ini ko‘rsatadi
+		cancelButton.Show()                                          // "Bekor qilish" tugmasini ko‘rsatadi
+		onProgress := newProgressHandler(progressBar, progressLabel) // Yuklash holatini ko‘rsatuvchi funksiya
+
+		j.Watch(func(info services.JobInfo) {
+			switch info.State {
+			case services.JobQueued: // Bo‘sh ishchini kutmoqda
+				progressLabel.Text = "Navbatda..."
+				progressLabel.Refresh()
+			case services.JobRunning:
+				onProgress(info.Progress)
+			case services.JobDone:
+				showState()
+				title := "Yuklandi va o'rnatildi"
+				switch info.Kind {
+				case services.JobUpdate:
+					title = "Yangilandi"
+				case services.JobRollback:
+					title = software.Name + " " + info.Installed.Version + " versiyasiga qaytarildi"
+				}
+				mainFile := filepath.Join(info.Installed.DirPath, info.Installed.MainFile) // Asosiy fayl yo‘li
+				fyne.CurrentApp().SendNotification(fyne.NewNotification(title, mainFile))  // Muvaffaqiyat xabarini ko‘rsatadi
+			case services.JobCanceled: // Foydalanuvchi bekor qilgan bo‘lsa, xatolik ko‘rsatilmaydi
+				showState()
+				fyne.CurrentApp().SendNotification(fyne.NewNotification("Bekor qilindi", software.Name+" yuklanishi bekor qilindi"))
+			case services.JobFailed:
+				showState()
+				prefix := "yuklashda xatolik"
+				switch info.Kind {
+				case services.JobUpdate: // Yangilash tranzaksiyasi orqaga qaytarilgan
+					prefix = "yangilashda xatolik, avvalgi versiya saqlab qolindi"
+				case services.JobRollback:
+					prefix = "orqaga qaytarishda xatolik, joriy versiya saqlab qolindi"
+				}
+				dialog.ShowError(downloadError(prefix, info.Err), myWindow)
+			}
+		})
+	}
+
+	// Dastur holatini tekshirish: navbatda bo‘lsa, vazifani kuzatadi, aks holda ro‘yxatga qaraydi
+	if active := queue.Active(software.ID); active != nil {
+		watchJob(active)
+	} else {
+		showState()
+	}
+
 	// O‘chirish tugmasi funksiyasi
 	deleteButton.OnTapped = func() {
 		dialog.ShowConfirm("O'chirish", "Ushbu dasturni ro'yxatdan o'chirishni xohlaysizmi?", func(confirmed bool) {
 			if confirmed {
-				softwareData, err := storage.GetSoftwareByID(software.ID, "downloaded_software.json")
-				if err != nil {
-					if errors.Is(err, storage.ErrSoftwareNotFound) {
-						dialog.ShowError(fmt.Errorf("dastur topilmadi: %s", software.ID), myWindow)
-					} else {
-						dialog.ShowError(fmt.Errorf("ma'lumot olishda xatolik: %v", err), myWindow)
-					}
-					return
-				}
-
-				if softwareData.DirPath != "" {
-					err = os.RemoveAll(softwareData.DirPath)
-					if err != nil {
-						dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow)
-						return
-					}
-				}
-
-				err = storage.DeleteSoftware(software.ID, "downloaded_software.json")
+				_, err := installer.Remove(software.ID) // Papka, yozuv va yorliqlarni o‘chiradi
 				if err != nil {
 					switch {
+					case errors.Is(err, services.ErrInstallDir):
+						dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow)
 					case errors.Is(err, storage.ErrFileOpenFailed):
 						dialog.ShowError(fmt.Errorf("faylni ochishda xatolik: %v", err), myWindow)
 					case errors.Is(err, storage.ErrFileCreateFailed):
@@ -247,13 +428,7 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 					return
 				}
 
-				services.RemoveDesktopShortcut(softwareData.Name)
-				services.RemoveStartMenuShortcut(softwareData.Name)
-				services.RemoveStartupShortcut(softwareData.Name)
-				deleteButton.Hide()
-				updateButton.Hide()
-				downloadButton.Show()
-				openButton.Hide()
+				showState()
 				fyne.CurrentApp().SendNotification(fyne.NewNotification("O'chirildi", software.Name+" ro'yxatdan o'chirildi"))
 			}
 		}, myWindow)
@@ -261,209 +436,29 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 
 	// Yuklash tugmasi funksiyasi
 	downloadButton.OnTapped = func() { // "Yuklash" tugmasi bosilganda ishlaydi
-		folder := services.GetUserLocalPath()                      // Foydalanuvchi mahalliy papkasini oladi
-		services.DownloadPath = filepath.Join(folder, software.ID) // Yuklash yo‘lini dastur ID si bilan birlashtiradi
-
-		err = removeFolder(services.DownloadPath)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-		}
-
-		err = os.Mkdir(services.DownloadPath, 0755) // Yangi papka yaratadi (ruxsatlar: 0755)
-		if err != nil {                             // Agar xatolik bo‘lsa
-			dialog.ShowError(fmt.Errorf("papka yaratishda xatolik: %v", err), myWindow)
-			return // Funksiyadan chiqadi
-		}
-
-		fileURL := fmt.Sprintf("http://localhost:8080/appStore/download/%s", software.ID) // Yuklash URL sini yaratadi
-
-		downloadButton.Hide() // "Yuklash" tugmasini yashiradi
-		deleteButton.Hide()   // "O‘chirish" tugmasini yashiradi
-		updateButton.Hide()   // "Yangilash" tugmasini yashiradi
-		openButton.Hide()     // "Ochish" tugmasini yashiradi
-		progressBar.Show()    // Progress barni ko‘rsatadi
-
-		go func() { // Goroutine ishlatib, fon rejimida yuklashni amalga oshiradi
-			mainFilePath, iconFilePath, err := services.DownloadFile(fileURL, services.DownloadPath) // Faylni yuklaydi
-			if err != nil {                                                                          // Agar xatolik bo‘lsa
-				progressBar.Hide()    // Progress barni yashiradi
-				downloadButton.Show() // "Yuklash" tugmasini qayta ko‘rsatadi
-				dialog.ShowError(fmt.Errorf("yuklashda xatolik: %v", err), myWindow)
-				return // Funksiyadan chiqadi
-			}
-
-			softwareData := models.DownloadedSoftware{ // Yuklangan dastur ma’lumotlarini tayyorlaydi
-				ID:           software.ID,                              // Dastur ID si
-				Name:         software.Name,                            // Dastur nomi
-				Version:      software.Version,                         // Dastur versiyasi
-				DirPath:      services.DownloadPath,                    // Yuklash yo‘li
-				MainFile:     filepath.Base(mainFilePath),              // Asosiy fayl nomi
-				IconPath:     filepath.Base(iconFilePath),              // Ikonka fayl nomi
-				DownloadDate: time.Now().Format("2006-01-02 15:04:05"), // Yuklash sanasi
-				IsDesktop:    software.IsDesktop,
-				IsStartup:    software.IsStartup,
-				IsAutoStart:  software.IsAutoStart,
-			}
-
-			err = storage.SaveDownloadedSoftware(softwareData, "downloaded_software.json")
-			if err != nil {
-				switch {
-				case errors.Is(err, storage.ErrFileCreateFailed):
-					dialog.ShowError(fmt.Errorf("faylni yaratishda xatolik: %v", err), myWindow)
-				case errors.Is(err, storage.ErrJSONEncodeFailed):
-					dialog.ShowError(fmt.Errorf("JSON yozishda xatolik: %v", err), myWindow)
-				case errors.Is(err, storage.ErrFileOpenFailed):
-					dialog.ShowError(fmt.Errorf("faylni ochishda xatolik: %v", err), myWindow)
-				case errors.Is(err, storage.ErrJSONDecodeFailed):
-					dialog.ShowError(fmt.Errorf("JSON o'qishda xatolik: %v", err), myWindow)
-				default:
-					dialog.ShowError(fmt.Errorf("yuklashda noma'lum xatolik: %v", err), myWindow)
-				}
-				err = removeFolder(services.DownloadPath)
-				if err != nil {
-					dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-				}
-				progressBar.Hide()
-				downloadButton.Show()
-				return
-			}
-			progressBar.Hide()                                                                               // Progress barni yashiradi
-			deleteButton.Show()                                                                              // "O‘chirish" tugmasini ko‘rsatadi
-			openButton.Show()                                                                                // "Ochish" tugmasini ko‘rsatadi
-			fyne.CurrentApp().SendNotification(fyne.NewNotification("Yuklandi va o'rnatildi", mainFilePath)) // Muvaffaqiyat xabarini ko‘rsatadi
-
-			programPath := filepath.Join(softwareData.DirPath, softwareData.MainFile) // Dastur yo‘lini birlashtiradi
-			if softwareData.IsDesktop {
-				services.CreateDesktopShortcut(programPath, softwareData.Name) // Dastur yorlig'ini ishchi stoliga yaratish
-			}
-			if softwareData.IsStartup {
-				services.CreateStartMenuShortcut(programPath, softwareData.Name) // Dastur yorlig'ini start menyuga yaratish
-			}
-			if softwareData.IsAutoStart {
-				services.CreateStartupShortcut(programPath, softwareData.Name)
-			}
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("dastur o'rnatilishida xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-			}
-		}()
+		watchJob(queue.Enqueue(services.JobInstall, software)) // Navbatga qo‘shadi, bo‘sh ishchi yuklab o‘rnatadi
 	}
 
 	// Yangilash tugmasi funksiyasi
 	updateButton.OnTapped = func() { // "Yangilash" tugmasi bosilganda ishlaydi
-		data, err := storage.GetSoftwareByID(software.ID, "downloaded_software.json") // Dastur ma’lumotlarini oladi
-		if err != nil {                                                               // Agar xatolik bo‘lsa
-			dialog.ShowError(fmt.Errorf("dastur ma’lumotlarini olishd xatolik: %v", err), myWindow) // Xatolikni konsolga chiqaradi
-			data = &models.DownloadedSoftware{}                                                     // Bo‘sh obyekt yaratadi
-		}
-
-		softwareData := *data // Ma’lumotlarni dereference qiladi (ko‘rsatkichdan qiymatga)
-
-		softwareData = models.DownloadedSoftware{ // Yangi ma’lumotlar bilan to‘ldiradi
-			ID:           software.ID,                              // Dastur ID si
-			Name:         software.Name,                            // Dastur nomi
-			Version:      software.Version,                         // Yangi versiya
-			DirPath:      softwareData.DirPath,                     // Avvalgi papka yo‘li
-			MainFile:     software.MainFile,                        // Asosiy fayl
-			DownloadDate: time.Now().Format("2006-01-02 15:04:05"), // Yangilash sanasi
-			IsDesktop:    software.IsDesktop,
-			IsStartup:    software.IsStartup,
-			IsAutoStart:  software.IsAutoStart,
-		}
-
-		// Papkani o‘chirish
-		err = removeFolder(softwareData.DirPath)
-		if err != nil {
-			dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-		}
-
-		folder := services.GetUserLocalPath()                      // Foydalanuvchi mahalliy papkasini oladi
-		services.DownloadPath = filepath.Join(folder, software.ID) // Yangi yuklash yo‘lini yaratadi
-
-		err = os.Mkdir(services.DownloadPath, 0755) // Yangi papka yaratadi
-		if err != nil {                             // Agar xatolik bo‘lsa
-			dialog.ShowError(fmt.Errorf("papka yaratishda xatolik: %v", err), myWindow)
-			return // Funksiyadan chiqadi
-		}
-
-		services.RemoveDesktopShortcut(softwareData.Name) // Avvalgi yorliqni o‘chiradi
-		services.RemoveStartMenuShortcut(softwareData.Name)
-		services.RemoveStartupShortcut(softwareData.Name)
-
-		err = storage.SaveDownloadedSoftware(softwareData, "downloaded_software.json")
-		if err != nil {
-			switch {
-			case errors.Is(err, storage.ErrFileCreateFailed):
-				dialog.ShowError(fmt.Errorf("faylni yaratishda xatolik: %v", err), myWindow)
-			case errors.Is(err, storage.ErrJSONEncodeFailed):
-				dialog.ShowError(fmt.Errorf("JSON yozishda xatolik: %v", err), myWindow)
-			case errors.Is(err, storage.ErrFileOpenFailed):
-				dialog.ShowError(fmt.Errorf("faylni ochishda xatolik: %v", err), myWindow)
-			case errors.Is(err, storage.ErrJSONDecodeFailed):
-				dialog.ShowError(fmt.Errorf("JSON o'qishda xatolik: %v", err), myWindow)
-			default:
-				dialog.ShowError(fmt.Errorf("yuklashda noma'lum xatolik: %v", err), myWindow)
-			}
-			err = removeFolder(softwareData.DirPath)
-			if err != nil {
-				dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-			}
-			progressBar.Hide()
-			downloadButton.Show()
-			return
-		}
-		fileURL := fmt.Sprintf("http://localhost:8080/appStore/download/%s", software.ID) // Yuklash URL sini yaratadi
-
-		downloadButton.Hide()                                                    // "Yuklash" tugmasini yashiradi
-		deleteButton.Hide()                                                      // "O‘chirish" tugmasini yashiradi
-		updateButton.Hide()                                                      // "Yangilash" tugmasini yashiradi
-		openButton.Hide()                                                        // "Ochish" tugmasini yashiradi
-		progressBar.Show()                                                       // Progress barni ko‘rsatadi
-		mainFile, _, err := services.DownloadFile(fileURL, softwareData.DirPath) // Faylni yuklaydi
-		go func() {                                                              // Goroutine ishlatib, fon rejimida yangilashni amalga oshiradi
-			if err != nil { // Agar xatolik bo‘lsa
-				fmt.Println("Xatolik:", err) // Xatolikni konsolga chiqaradi
-				progressBar.Hide()           // Progress barni yashiradi
-				deleteButton.Show()          // "O‘chirish" tugmasini ko‘rsatadi
-				updateButton.Show()          // "Yangilash" tugmasini qayta ko‘rsatadi
-				err = removeFolder(softwareData.DirPath)
-				if err != nil {
-					dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-				}
-				dialog.ShowError(fmt.Errorf("faylni yuklashda xatolik: %v", err), myWindow)
-				return // Funksiyadan chiqadi
-			}
+		watchJob(queue.Enqueue(services.JobUpdate, software)) // Navbatga qo‘shadi, bo‘sh ishchi yangilaydi
+	}
 
-			programPath := filepath.Join(softwareData.DirPath, softwareData.MainFile) // Dastur yo‘lini birlashtiradi
-			if softwareData.IsDesktop {
-				err = services.CreateDesktopShortcut(programPath, softwareData.Name) // Yangi yorliq yaratadi
-			}
-			if softwareData.IsStartup {
-				err = services.CreateStartMenuShortcut(programPath, softwareData.Name) // Yangi yorliq yaratadi
-			}
-			if softwareData.IsAutoStart {
-				err = services.CreateStartupShortcut(programPath, softwareData.Name) // Yangi yorliq yaratadi
-			}
-			if err != nil {
-				err = removeFolder(softwareData.DirPath)
-				if err != nil {
-					dialog.ShowError(fmt.Errorf("papkani o'chirishda xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
-				}
-				dialog.ShowError(fmt.Errorf("dastur o'rnatilishida xatolik: %v", err), myWindow) // Agar xatolik bo‘lsa
+	// "Orqaga qaytarish" tugmasi funksiyasi
+	rollbackButton.OnTapped = func() {
+		prev := previousVersion
+		dialog.ShowConfirm("Orqaga qaytarish", fmt.Sprintf("%s dasturini %s versiyasiga qaytarishni xohlaysizmi?", software.Name, prev), func(confirmed bool) {
+			if confirmed {
+				target := models.Software{ID: software.ID, Name: software.Name, Version: prev} // Qaytariladigan versiya
+				watchJob(queue.Enqueue(services.JobRollback, target))
 			}
-
-			progressBar.Hide()  // Progress barni yashiradi
-			openButton.Show()   // "Ochish" tugmasini ko‘rsatadi
-			deleteButton.Show() // "O‘chirish" tugmasini ko‘rsatadi
-
-			filePath := filepath.Join(services.DownloadPath, mainFile)                       // Yuklangan fayl yo‘lini birlashtiradi
-			fyne.CurrentApp().SendNotification(fyne.NewNotification("Yangilandi", filePath)) // Muvaffaqiyat xabarini ko‘rsatadi
-		}()
+		}, myWindow)
 	}
 
 	// "Ochish" tugmasi funksiyasi
 	openButton.OnTapped = func() { // "Ochish" tugmasi bosilganda ishlaydi
-		softwareData, err := storage.GetSoftwareByID(software.ID, "downloaded_software.json") // Dastur ma’lumotlarini oladi
-		if err != nil {                                                                       // Agar xatolik bo‘lsa
+		softwareData, err := storage.GetSoftwareByID(software.ID, installer.RegistryPath) // Dastur ma’lumotlarini oladi
+		if err != nil {                                                                   // Agar xatolik bo‘lsa
 			fmt.Println("", err) // Xatolikni konsolga chiqaradi
 			dialog.ShowError(fmt.Errorf("dastur ma'lumotlarini olishda xatolik: %v", err), myWindow)
 			return // Funksiyadan chiqadi
@@ -486,7 +481,7 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 
 	// Tugmalar konteyneri
 	buttonContainer := container.NewVBox( // Tugmalarni vertikal tartibda joylashtiradi
-		progressBar,    // Progress bar
+		cancelButton,   // "Bekor qilish" tugmasi
 		deleteButton,   // "O‘chirish" tugmasi
 		downloadButton, // "Yuklash" tugmasi
 		updateButton,   // "Yangilash" tugmasi
@@ -498,6 +493,11 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 	content := container.NewWithoutLayout( // Tartibsiz konteyner yaratadi
 		title,           // Dastur nomi
 		iconImage,       // Ikonka tasviri
+		verifiedBadge,   // Tasdiqlangan nashriyotchi belgisi
+		newerBadge,      // O‘rnatilgan versiya yangiroq belgisi
+		rollbackButton,  // Orqaga qaytarish tugmasi
+		progressLabel,   // Tezlik va qolgan vaqt
+		progressBar,     // Progress bar
 		buttonContainer, // Tugmalar konteyneri
 	)
 
@@ -506,6 +506,23 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 	iconImage.Move(fyne.NewPos(7, 35))       // Ikonkani (7, 35) koordinatasiga joylashtiradi
 	iconImage.Resize(fyne.NewSize(100, 100)) // Ikonka o‘lchamini 100x100 ga o‘zgartiradi
 
+	// Tasdiqlangan nashriyotchi belgisini ikonkaning yuqori chap burchagiga joylashtiramiz
+	verifiedBadge.Move(fyne.NewPos(7, 35))     // Belgini (7, 35) koordinatasiga joylashtiradi
+	verifiedBadge.Resize(fyne.NewSize(20, 20)) // Belgi o‘lchamini 20x20 ga sozlaydi
+
+	// Versiya belgisini ikonkaning yuqori o‘ng burchagiga joylashtiramiz
+	newerBadge.Move(fyne.NewPos(87, 35))    // Belgini (87, 35) koordinatasiga joylashtiradi
+	newerBadge.Resize(fyne.NewSize(20, 20)) // Belgi o‘lchamini 20x20 ga sozlaydi
+
+	// Orqaga qaytarish tugmasini ikonkaning pastki o‘ng burchagiga joylashtiramiz
+	rollbackButton.Move(fyne.NewPos(81, 109))   // Tugmani (81, 109) koordinatasiga joylashtiradi
+	rollbackButton.Resize(fyne.NewSize(26, 26)) // Tugma o‘lchamini 26x26 ga sozlaydi
+
+	// Progress bar va tezlik yorlig‘ini ikonkaning pastki qismiga joylashtiramiz
+	progressLabel.Move(fyne.NewPos(7, 100))   // Yorliqni (7, 100) koordinatasiga joylashtiradi
+	progressBar.Move(fyne.NewPos(7, 115))     // Progress barni (7, 115) koordinatasiga joylashtiradi
+	progressBar.Resize(fyne.NewSize(105, 18)) // Progress bar o‘lchamini 105x18 ga sozlaydi
+
 	// title ning joylashuvini belgilaymiz
 	title.Move(fyne.NewPos(50, 5)) // Nomni (50, 5) koordinatasiga joylashtiradi
 
@@ -520,12 +537,34 @@ func createSoftwareCard(software models.Software, descriptionLabel *widget.Label
 	return card // Tayyor kartani qaytaradi
 }
 
-func removeFolder(path string) error {
-	if _, err := os.Stat(path); err == nil { // Agar papka mavjud bo‘lsa
-		err = os.RemoveAll(path) // Papkani o‘chiradi
-		if err != nil {
-			return err // Funksiyadan chiqadi
-		}
+// catalogErrors - manbalar xatoliklarini bitta xatolikka birlashtiradi
+func catalogErrors(errs []error) error {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// downloadError - yuklash xatoligini foydalanuvchiga ko‘rsatish uchun tayyorlaydi.
+// Paket xavfli yozuv sababli rad etilgan bo‘lsa, yozuv nomi va sababi alohida ko‘rsatiladi.
+func downloadError(prefix string, err error) error {
+	var unsafeErr *services.UnsafeEntryError
+	switch {
+	case errors.As(err, &unsafeErr): // Paket ichida xavfli yozuv bo‘lsa
+		return fmt.Errorf("%s: paket rad etildi, %q yozuvi xavfli (%v)", prefix, unsafeErr.Entry, unsafeErr.Err)
+	case errors.Is(err, services.ErrTimeout): // Server javob bermadi
+		return fmt.Errorf("%s: server javob bermadi, keyinroq qayta urinib ko'ring (%v)", prefix, err)
+	case errors.Is(err, services.ErrChecksumMismatch), errors.Is(err, services.ErrSizeMismatch): // Paket buzilgan bo‘lsa
+		return fmt.Errorf("%s: paket buzilgan yoki o'zgartirilgan, o'rnatilmadi (%v)", prefix, err)
+	case errors.Is(err, services.ErrSignatureMissing), errors.Is(err, services.ErrUnknownPublisher),
+		errors.Is(err, services.ErrSignatureInvalid): // Nashriyotchi tasdiqlanmagan bo‘lsa
+		return fmt.Errorf("%s: nashriyotchi tasdiqlanmadi, o'rnatilmadi (%v)", prefix, err)
+	case errors.Is(err, services.ErrInstallDir): // Papkani yaratib yoki tozalab bo‘lmadi
+		return fmt.Errorf("%s: o'rnatish papkasini tayyorlab bo'lmadi (%v)", prefix, err)
+	case errors.Is(err, storage.ErrFileOpenFailed), errors.Is(err, storage.ErrFileCreateFailed),
+		errors.Is(err, storage.ErrJSONDecodeFailed), errors.Is(err, storage.ErrJSONEncodeFailed): // Ro‘yxat fayli bilan muammo
+		return fmt.Errorf("%s: o'rnatilgan dasturlar ro'yxatiga yozib bo'lmadi (%v)", prefix, err)
 	}
-	return nil
+	return fmt.Errorf("%s: %v", prefix, err)
 }
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+
+	"main/services"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	downloadsMu     sync.Mutex  // downloadsWindow ni himoyalaydi
+	downloadsWindow fyne.Window // Ochiq "Yuklamalar" oynasi (bitta nusxa)
+)
+
+// showDownloads - navbatdagi, bajarilayotgan, tugagan va xatolik bilan tugagan vazifalarni alohida oynada ko‘rsatadi
+func showDownloads(queue *services.Queue) {
+	downloadsMu.Lock()
+	defer downloadsMu.Unlock()
+	if downloadsWindow != nil { // Oyna allaqachon ochiq bo‘lsa, uni oldinga chiqaradi
+		downloadsWindow.RequestFocus()
+		return
+	}
+
+	var (
+		mu   sync.Mutex     // jobs ni himoyalaydi (kuzatuvchi boshqa goroutine dan chaqiriladi)
+		jobs = queue.Jobs() // Ro‘yxatdagi vazifalar nusxasi
+	)
+	snapshot := func(i int) (services.JobInfo, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if i < 0 || i >= len(jobs) {
+			return services.JobInfo{}, false
+		}
+		return jobs[i], true
+	}
+
+	list := widget.NewList(
+		func() int { // Vazifalar soni
+			mu.Lock()
+			defer mu.Unlock()
+			return len(jobs)
+		},
+		func() fyne.CanvasObject { // Bitta qator shabloni: nom, holat va "Bekor qilish" tugmasi
+			name := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			status := widget.NewLabel("")
+			cancel := widget.NewButtonWithIcon("", theme.CancelIcon(), nil)
+			cancel.Importance = widget.LowImportance
+			return container.NewBorder(nil, nil, nil, cancel, container.NewVBox(name, status))
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) { // Qatorni vazifa holati bilan to‘ldiradi
+			info, ok := snapshot(id)
+			if !ok {
+				return
+			}
+			row := item.(*fyne.Container)
+			texts := row.Objects[0].(*fyne.Container)
+			cancel := row.Objects[1].(*widget.Button)
+			texts.Objects[0].(*widget.Label).SetText(fmt.Sprintf("%s — %s %s", info.Software.Name, info.Kind, info.Software.Version))
+			texts.Objects[1].(*widget.Label).SetText(jobStatus(info))
+			cancel.OnTapped = func() { queue.Cancel(info.ID) }
+			if info.State.Finished() {
+				cancel.Hide()
+			} else {
+				cancel.Show()
+			}
+		},
+	)
+
+	refresh := func() { // Navbatdan yangi nusxani olib, ro‘yxatni qayta chizadi
+		mu.Lock()
+		jobs = queue.Jobs()
+		mu.Unlock()
+		list.Refresh()
+	}
+	unsubscribe := queue.Subscribe(func(services.JobInfo) { refresh() })
+
+	clearButton := widget.NewButtonWithIcon("Tugaganlarini tozalash", theme.DeleteIcon(), func() {
+		queue.ClearFinished() // Tayyor, xatolik va bekor qilingan vazifalarni olib tashlaydi
+		refresh()
+	})
+
+	w := fyne.CurrentApp().NewWindow("Yuklamalar")
+	w.SetContent(container.NewBorder(nil, container.NewPadded(clearButton), nil, nil, list))
+	w.Resize(fyne.NewSize(420, 360))
+	w.SetOnClosed(func() {
+		unsubscribe() // Yopilgan oynani endi yangilamaydi
+		downloadsMu.Lock()
+		downloadsWindow = nil
+		downloadsMu.Unlock()
+	})
+	downloadsWindow = w
+	w.Show()
+}
+
+// jobStatus - vazifa holatini bir qatorli matnga aylantiradi
+func jobStatus(info services.JobInfo) string {
+	switch info.State {
+	case services.JobRunning:
+		p := info.Progress
+		switch p.Stage {
+		case services.StageVerify:
+			return "Tekshirilmoqda..."
+		case services.StageExtract:
+			return fmt.Sprintf("Ochilmoqda %d/%d", p.FilesExtracted, p.TotalFiles)
+		}
+		if p.TotalBytes > 0 {
+			return fmt.Sprintf("Yuklanmoqda %s / %s", formatBytes(p.BytesReceived), formatBytes(p.TotalBytes))
+		}
+		return fmt.Sprintf("Yuklanmoqda %s", formatBytes(p.BytesReceived))
+	case services.JobFailed:
+		return downloadError(info.State.String(), info.Err).Error()
+	case services.JobDone:
+		return fmt.Sprintf("%s (%s)", info.State, info.Finished.Format("15:04:05"))
+	}
+	return info.State.String()
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"main/services"
+
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newProgressHandler - yuklash xabarlarini progress bar va tezlik/ETA yorlig‘iga aylantiradi
+func newProgressHandler(bar *widget.ProgressBar, label *canvas.Text) services.ProgressFunc {
+	var (
+		start     = time.Now() // Yuklash boshlangan vaqt
+		lastTime  = start      // Oldingi xabar vaqti
+		lastBytes int64        // Oldingi xabardagi baytlar
+		speed     float64      // Silliqlangan tezlik (bayt/soniya)
+	)
+
+	bar.Min, bar.Max = 0, 1 // Qiymat 0..1 oralig‘ida
+	bar.SetValue(0)
+	label.Text = ""
+	label.Refresh()
+
+	return func(p services.Progress) {
+		switch p.Stage {
+		case services.StageDownload:
+			now := time.Now()
+			if dt := now.Sub(lastTime).Seconds(); dt > 0 && p.BytesReceived > lastBytes {
+				current := float64(p.BytesReceived-lastBytes) / dt // Oxirgi oraliqdagi tezlik
+				if speed == 0 {
+					speed = current
+				} else {
+					speed = 0.7*speed + 0.3*current // Sakrashlarni silliqlaydi
+				}
+				lastTime, lastBytes = now, p.BytesReceived
+			}
+
+			if p.TotalBytes > 0 { // Hajm ma'lum bo‘lsa, aniq foiz va qolgan vaqt
+				bar.SetValue(float64(p.BytesReceived) / float64(p.TotalBytes))
+				label.Text = fmt.Sprintf("%s/s · %s", formatBytes(int64(speed)), formatETA(p.TotalBytes-p.BytesReceived, speed))
+			} else { // Hajm noma'lum bo‘lsa, faqat olingan baytlar va tezlik
+				label.Text = fmt.Sprintf("%s · %s/s", formatBytes(p.BytesReceived), formatBytes(int64(speed)))
+			}
+		case services.StageVerify:
+			bar.SetValue(1)
+			label.Text = "Tekshirilmoqda..."
+		case services.StageExtract:
+			if p.TotalFiles > 0 {
+				bar.SetValue(float64(p.FilesExtracted) / float64(p.TotalFiles))
+			}
+			label.Text = fmt.Sprintf("Ochilmoqda %d/%d", p.FilesExtracted, p.TotalFiles)
+		}
+		label.Refresh()
+	}
+}
+
+// formatBytes - baytlarni o‘qish oson ko‘rinishga keltiradi (masalan "1.5 MB")
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+// formatETA - qolgan baytlar va tezlikdan taxminiy qolgan vaqtni hisoblaydi
+func formatETA(remaining int64, speed float64) string {
+	if speed <= 0 {
+		return "--:--"
+	}
+	eta := time.Duration(float64(remaining) / speed * float64(time.Second)).Round(time.Second)
+	if eta >= time.Hour {
+		return fmt.Sprintf("%d:%02d:%02d", int(eta.Hours()), int(eta.Minutes())%60, int(eta.Seconds())%60)
+	}
+	return fmt.Sprintf("%02d:%02d", int(eta.Minutes()), int(eta.Seconds())%60)
+}
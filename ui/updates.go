@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"main/config"
+	"main/services"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// StartUpdateChecker - yangilanishlarni fonda tekshirishni boshlaydi.
+// Yangi versiyalar topilganda bildirishnoma yuboriladi; "Hammasini yangilash" tizim tepsisi menyusida.
+// Tepsi mavjud bo‘lsa, oyna yopilganda dastur fonda ishlashda davom etadi.
+func StartUpdateChecker(myWindow fyne.Window, updater *services.Updater) {
+	a := fyne.CurrentApp()
+
+	updateAllItem := fyne.NewMenuItem("Hammasini yangilash", nil) // Topilgan barcha yangilanishlarni navbatga qo‘shadi
+	updateAllItem.Disabled = true                                 // Yangilanish topilmaguncha o‘chiq
+	var menu *fyne.Menu
+	setCount := func(n int) { // Menyudagi yangilanishlar sonini yangilaydi
+		if menu == nil {
+			return
+		}
+		updateAllItem.Label = "Hammasini yangilash"
+		if n > 0 {
+			updateAllItem.Label = fmt.Sprintf("Hammasini yangilash (%d)", n)
+		}
+		updateAllItem.Disabled = n == 0
+		menu.Refresh()
+	}
+
+	updateAllItem.Action = func() {
+		jobs := updater.UpdateAll()
+		setCount(0)
+		if len(jobs) == 0 {
+			return
+		}
+		a.SendNotification(fyne.NewNotification("Yangilanmoqda", fmt.Sprintf("%d ta dastur yangilash navbatiga qo'shildi", len(jobs))))
+	}
+
+	updater.OnUpdates = func(updates []services.Update) {
+		names := make([]string, len(updates))
+		for i, update := range updates {
+			names[i] = fmt.Sprintf("%s %s", update.Installed.Name, update.Available.Version)
+		}
+		a.SendNotification(fyne.NewNotification("Yangilanishlar mavjud", strings.Join(names, ", ")))
+		setCount(len(updater.Updates()))
+	}
+	var errMu sync.Mutex
+	lastError := "" // Oxirgi bildirilgan xatolik: bir xil xatolik har tekshiruvda qayta bildirilmaydi
+	updater.OnError = func(errs []error) {
+		err := catalogErrors(errs)
+		fmt.Fprintln(os.Stderr, "Yangilanishlarni tekshirishda xatolik:", err)
+		errMu.Lock()
+		repeated := err.Error() == lastError
+		lastError = err.Error()
+		errMu.Unlock()
+		if !repeated { // Oyna yashirin bo‘lishi mumkin, shuning uchun bildirishnoma orqali
+			a.SendNotification(fyne.NewNotification("Yangilanishlarni tekshirishda xatolik", err.Error()))
+		}
+	}
+
+	if desk, ok := a.(desktop.App); ok { // Tizim tepsisi (faqat kompyuterlarda)
+		menu = fyne.NewMenu("appStore",
+			fyne.NewMenuItem("Oynani ochish", func() {
+				myWindow.Show()
+				myWindow.RequestFocus()
+			}),
+			fyne.NewMenuItem("Yangilanishlarni tekshirish", func() {
+				go func() {
+					updates, errs := updater.Check(context.Background())
+					setCount(len(updates))
+					switch {
+					case len(errs) > 0 && len(updates) == 0:
+						a.SendNotification(fyne.NewNotification("Xatolik", catalogErrors(errs).Error()))
+					case len(updates) == 0:
+						a.SendNotification(fyne.NewNotification("Yangilanishlar", "Barcha dasturlar eng so'nggi versiyada"))
+					default:
+						a.SendNotification(fyne.NewNotification("Yangilanishlar mavjud", fmt.Sprintf("%d ta dasturning yangi versiyasi bor", len(updates))))
+					}
+				}()
+			}),
+			updateAllItem,
+		)
+		desk.SetSystemTrayMenu(menu)
+		myWindow.SetCloseIntercept(myWindow.Hide) // Oyna yashiriladi, tekshiruv fonda davom etadi
+	}
+
+	go updater.Run(context.Background())
+}
+
+// updateAll - yangilanishi mavjud barcha o‘rnatilgan dasturlarni navbatga qo‘shadi
+// (bir vaqtda ishchilar sonicha) va hammasi tugagach bitta xulosa ko‘rsatadi
+func updateAll(myWindow fyne.Window, cfg *config.Config, queue *services.Queue) {
+	jobs, errs := services.NewUpdater(queue, 0).UpdateOutdated(context.Background())
+	if len(jobs) == 0 {
+		if len(errs) > 0 {
+			dialog.ShowError(fmt.Errorf("yangilanishlarni tekshirishda xatolik: %v", catalogErrors(errs)), myWindow)
+			return
+		}
+		dialog.ShowInformation("Yangilanishlar", "Barcha dasturlar eng so'nggi versiyada", myWindow)
+		return
+	}
+	SetupUI(myWindow, cfg, queue) // Kartalar navbatdagi vazifalarni kuzatib, holatini ko‘rsatadi
+
+	result := services.WaitJobs(jobs)
+	lines := []string{fmt.Sprintf("Yangilandi: %d, xatolik: %d", len(result.Done), len(result.Failed))}
+	for _, info := range result.Done {
+		lines = append(lines, fmt.Sprintf("✓ %s %s", info.Software.Name, info.Installed.Version))
+	}
+	for _, info := range result.Failed {
+		lines = append(lines, "✗ "+downloadError(info.Software.Name, info.Err).Error())
+	}
+	if len(errs) > 0 { // Ba'zi kataloglar tekshirilmagan
+		lines = append(lines, fmt.Sprintf("Ba'zi kataloglar yuklanmadi: %v", catalogErrors(errs)))
+	}
+	fyne.CurrentApp().SendNotification(fyne.NewNotification("Yangilash yakunlandi", lines[0]))
+	dialog.ShowInformation("Yangilash yakunlandi", strings.Join(lines, "\n"), myWindow)
+}
@@ -0,0 +1,258 @@
+package version
+
+import (
+	"errors"  // Maxsus xatoliklarni yaratish uchun
+	"fmt"     // Xatolik xabarlarini formatlash uchun
+	"strconv" // Raqamli qismlarni o‘qish uchun
+	"strings" // Versiya satrini bo‘laklash uchun
+)
+
+// ErrInvalid - satr semver formatiga mos emas
+var ErrInvalid = errors.New("noto'g'ri semver versiya")
+
+// Version - semver 2.0.0 versiyasi (MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD], oldida "v" bo‘lishi mumkin)
+type Version struct {
+	Major, Minor, Patch uint64
+	Pre                 []string // Pre-release qismlari (masalan "beta", "2")
+	Build               string   // Build metama'lumoti (taqqoslashda hisobga olinmaydi)
+}
+
+// Parse - satrni semver sifatida o‘qiydi
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 { // Build metama'lumoti
+		v.Build = rest[i+1:]
+		rest = rest[:i]
+		if !validIdentifiers(v.Build, false) {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 { // Pre-release
+		pre := rest[i+1:]
+		rest = rest[:i]
+		if !validIdentifiers(pre, true) {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		v.Pre = strings.Split(pre, ".")
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalid, s)
+	}
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		if !isNumeric(p) || (len(p) > 1 && p[0] == '0') { // Bo‘sh yoki boshida 0 bo‘lgan son semver emas
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalid, s)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+// String - versiyani semver ko‘rinishida qaytaradi
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Pre) > 0 {
+		s += "-" + strings.Join(v.Pre, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare - semver qoidalari bo‘yicha taqqoslaydi: v < o bo‘lsa -1, teng bo‘lsa 0, katta bo‘lsa 1.
+// Build metama'lumoti e'tiborga olinmaydi; pre-release versiya oddiy versiyadan kichik.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePre(v.Pre, o.Pre)
+}
+
+// Compare - ikki versiya satrini taqqoslaydi: a < b bo‘lsa -1, teng bo‘lsa 0, katta bo‘lsa 1.
+// Ikkalasi semver bo‘lsa, semver qoidalari ishlatiladi; aks holda nuqtali raqamli taqqoslash
+// (masalan "1.10" > "1.9", "2024.1.15" > "2024.1.2").
+func Compare(a, b string) int {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+	if errA == nil && errB == nil {
+		return va.Compare(vb)
+	}
+	return compareDotted(a, b)
+}
+
+// compareDotted - semver bo‘lmagan versiyalarni taqqoslaydi: "+build" tashlanadi, "-" dan keyingi qism
+// pre-release deb olinadi, asosiy qism nuqtalar bo‘yicha birma-bir taqqoslanadi.
+// Yetishmagan qismlar 0 deb olinadi ("1.2" == "1.2.0"); raqamli qismlar son sifatida,
+// qolganlari satr sifatida taqqoslanadi.
+func compareDotted(a, b string) int {
+	na, preA := splitLoose(a)
+	nb, preB := splitLoose(b)
+	for i := 0; i < len(na) || i < len(nb); i++ {
+		x, y := "0", "0"
+		if i < len(na) {
+			x = na[i]
+		}
+		if i < len(nb) {
+			y = nb[i]
+		}
+		if x == "" { // "1..2" yoki bo‘sh versiya
+			x = "0"
+		}
+		if y == "" {
+			y = "0"
+		}
+		if c := compareIdentifier(x, y); c != 0 {
+			return c
+		}
+	}
+	return comparePre(preA, preB)
+}
+
+// splitLoose - erkin formatdagi versiyani asosiy qismlar va pre-release qismlariga ajratadi
+func splitLoose(s string) (nums, pre []string) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		if s[i+1:] != "" {
+			pre = strings.Split(s[i+1:], ".")
+		}
+		s = s[:i]
+	}
+	return strings.Split(s, "."), pre
+}
+
+// comparePre - pre-release qismlarini taqqoslaydi; pre-release siz versiya katta (1.0.0 > 1.0.0-beta)
+func comparePre(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareIdentifier(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(a), len(b)) // Ko‘proq qismli pre-release katta
+}
+
+// compareIdentifier - ikkalasi son bo‘lsa, son sifatida; aks holda satr sifatida taqqoslaydi
+// (semver dagidek raqamli qism harfli qismdan kichik)
+func compareIdentifier(x, y string) int {
+	xNum, yNum := isNumeric(x), isNumeric(y)
+	switch {
+	case xNum && yNum:
+		x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0") // Katta sonlar uchun uzunlik bo‘yicha
+		if c := compareInt(len(x), len(y)); c != 0 {
+			return c
+		}
+		return strings.Compare(x, y)
+	case xNum:
+		return -1
+	case yNum:
+		return 1
+	}
+	return strings.Compare(x, y)
+}
+
+// validIdentifiers - pre-release yoki build qismlari to‘g‘riligini tekshiradi
+func validIdentifiers(s string, pre bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		for _, r := range id {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+		if pre && len(id) > 1 && id[0] == '0' && isNumeric(id) { // Pre-release dagi son boshida 0 bo‘lmaydi
+			return false
+		}
+	}
+	return true
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Status - o‘rnatilgan va katalogdagi versiyalar munosabati
+type Status int
+
+const (
+	UpToDate        Status = iota // O‘rnatilgan versiya katalogdagiga teng
+	UpdateAvailable               // Katalogda yangiroq versiya bor
+	InstalledNewer                // O‘rnatilgan versiya katalogdagidan yangiroq (eski versiya taklif qilinmaydi)
+)
+
+func (s Status) String() string {
+	switch s {
+	case UpdateAvailable:
+		return "Yangilanish mavjud"
+	case InstalledNewer:
+		return "O'rnatilgan versiya yangiroq"
+	}
+	return "Eng so'nggi versiya"
+}
+
+// Check - o‘rnatilgan versiyani katalogdagi versiya bilan solishtiradi
+func Check(installed, available string) Status {
+	switch Compare(installed, available) {
+	case -1:
+		return UpdateAvailable
+	case 1:
+		return InstalledNewer
+	}
+	return UpToDate
+}
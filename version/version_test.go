@@ -0,0 +1,99 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// semver
+		{"1.0.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.10", "1.0.9", 1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+
+		// pre-release tartibi (semver 2.0.0, 11-band)
+		{"1.0.0-alpha", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"1.0.0-beta", "1.0.0-beta.2", -1},
+		{"1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.1-alpha", "1.0.0", 1},
+
+		// nuqtali (semver emas)
+		{"1.10", "1.9", 1},
+		{"2024.1.15", "2024.1.2", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.2", "1.2.0.1", -1},
+		{"1.0.0.0", "1.0.0", 0},
+		{"01.2", "1.2", 0},
+		{"1.2-beta", "1.2", -1},
+		{"1.2a", "1.2", 1},
+		{"", "0", 0},
+
+		// semver va nuqtali aralash
+		{"1.2", "1.2.1", -1},
+		{"1.2.3.4", "1.2.3", 1},
+		{"1.2.3-beta", "1.2.3.0", -1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, kutilgan %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Compare(tt.b, tt.a); got != -tt.want { // Taqqoslash simmetrik bo‘lishi kerak
+			t.Errorf("Compare(%q, %q) = %d, kutilgan %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	valid := map[string]string{
+		"1.2.3":                "1.2.3",
+		"v0.0.1":               "0.0.1",
+		"1.0.0-rc.1+build.5":   "1.0.0-rc.1+build.5",
+		"1.0.0-x-y.0a":         "1.0.0-x-y.0a",
+		"10.20.30+meta-data.1": "10.20.30+meta-data.1",
+	}
+	for in, want := range valid {
+		v, err := Parse(in)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", in, err)
+			continue
+		}
+		if v.String() != want {
+			t.Errorf("Parse(%q).String() = %q, kutilgan %q", in, v.String(), want)
+		}
+	}
+	for _, in := range []string{"", "1.2", "1.2.3.4", "01.2.3", "1.2.3-", "1.2.3-01", "1.2.3-a..b", "1.2.3+", "1.2.x", "1.2.3-beta!"} {
+		if _, err := Parse(in); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Parse(%q) = %v, kutilgan ErrInvalid", in, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		installed, available string
+		want                 Status
+	}{
+		{"1.0.0", "1.0.0", UpToDate},
+		{"1.0.0", "1.0.1", UpdateAvailable},
+		{"1.0.0-beta", "1.0.0", UpdateAvailable},
+		{"1.10", "1.9", InstalledNewer},
+		{"2.0.0", "1.9.9", InstalledNewer},
+	}
+	for _, tt := range tests {
+		if got := Check(tt.installed, tt.available); got != tt.want {
+			t.Errorf("Check(%q, %q) = %v, kutilgan %v", tt.installed, tt.available, got, tt.want)
+		}
+	}
+}